@@ -0,0 +1,58 @@
+package webgeo
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// AssetHosts maps country codes (e.g. "CN") or continent codes after
+// ContinentPrefix (e.g. "continent:AS") to the CDN host serving static assets
+// there. A country entry wins over its continent, the "" entry is the
+// default.
+var AssetHosts = map[string]string{}
+
+// AssetDirectives are the CSP directives that load from the asset host
+var AssetDirectives = []string{"script-src", "style-src", "img-src", "font-src", "media-src"}
+
+// AssetHost returns the asset origin for the visitor
+func AssetHost(res Result) string {
+	return ForCountry(AssetHosts, res.Country, res.ContinentCode(), AssetHosts[""])
+}
+
+// ContentSecurityPolicy assembles a policy where default-src and
+// AssetDirectives allow 'self' and the visitor's asset host. extra adds
+// sources per directive, e.g. {"connect-src": {"https://api.example.com"}}
+func ContentSecurityPolicy(res Result, extra map[string][]string) string {
+	host := AssetHost(res)
+	directives := map[string][]string{"default-src": {"'self'"}}
+	order := []string{"default-src"}
+	for _, d := range AssetDirectives {
+		directives[d] = []string{"'self'"}
+		if host != "" {
+			directives[d] = append(directives[d], host)
+		}
+		order = append(order, d)
+	}
+	names := make([]string, 0, len(extra))
+	for d := range extra {
+		names = append(names, d)
+	}
+	sort.Strings(names)
+	for _, d := range names {
+		if _, pres := directives[d]; !pres {
+			order = append(order, d)
+		}
+		directives[d] = append(directives[d], extra[d]...)
+	}
+	parts := make([]string, 0, len(order))
+	for _, d := range order {
+		parts = append(parts, d+" "+strings.Join(directives[d], " "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// SetContentSecurityPolicy writes the Content-Security-Policy header for the visitor
+func SetContentSecurityPolicy(w http.ResponseWriter, res Result, extra map[string][]string) {
+	w.Header().Set("Content-Security-Policy", ContentSecurityPolicy(res, extra))
+}
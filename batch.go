@@ -0,0 +1,119 @@
+package webgeo
+
+import (
+	"net"
+	"net/netip"
+)
+
+// LookupBatch resolves many IPs at once, e.g. for nightly log enrichment.
+// Each cache shard is locked and the database readers are acquired once per batch
+// instead of once per IP. Results are in the order of ips and carry the
+// languages of the country only; invalid addresses get "ZZ".
+func LookupBatch(ips []netip.Addr) []Result {
+	return defaultGeolocator.LookupBatch(ips)
+}
+
+func (g *Geolocator) LookupBatch(ips []netip.Addr) []Result {
+	results, _ := g.LookupBatchE(ips)
+	return results
+}
+
+// LookupBatchE is LookupBatch with the first error of the lookups, cached
+// failures included, so a broken database isn't mistaken for IPs it
+// doesn't know. The results are complete either way.
+func LookupBatchE(ips []netip.Addr) ([]Result, error) {
+	return defaultGeolocator.LookupBatchE(ips)
+}
+
+func (g *Geolocator) LookupBatchE(ips []netip.Addr) ([]Result, error) {
+	entries, keys, _ := g.lookupBatch(ips)
+	results := make([]Result, len(ips))
+	var firstErr error
+	for i, e := range entries {
+		if keys[i] == "" {
+			e = newGeoEntry(nil, nil)
+		}
+		if firstErr == nil {
+			firstErr = e.Err
+		}
+		results[i] = geoResult(e.complete().forIP(ips[i].Unmap().String(), keys[i]))
+	}
+	return results, firstErr
+}
+
+// The Result of a lookup without a request: the languages of the country
+func geoResult(e CacheEntry) Result {
+	res := Result{Country: e.country, Langs: tagStrings(e.langs), Sources: map[string]Source{}, Geo: e.Geo}
+	for _, l := range res.Langs {
+		res.Sources[l] = SourceGeo
+	}
+	res.Currency, res.CurrencyName = CurrencyFor(res.Country)
+	res.CallingCode = CallingCodeFor(res.Country)
+	return res
+}
+
+// Warm looks up ips ahead of their requests, e.g. the busiest client
+// networks at startup, so the first requests after a deploy are served from
+// the cache. It returns the first error of the lookups.
+func Warm(ips []netip.Addr) error {
+	return defaultGeolocator.Warm(ips)
+}
+
+func (g *Geolocator) Warm(ips []netip.Addr) error {
+	_, _, err := g.lookupBatch(ips)
+	return err
+}
+
+// The cache entries of ips with their keys, "" for invalid addresses, and
+// the first error of the lookups that missed the cache
+func (g *Geolocator) lookupBatch(ips []netip.Addr) ([]CacheEntry, []string, error) {
+	keys := make([]string, len(ips))
+	for i, addr := range ips {
+		if addr.IsValid() {
+			keys[i] = g.cacheKey(addr.Unmap().String())
+		}
+	}
+	g.flushOnSwap()
+	entries, found := getMany(g.cache, keys)
+
+	// look up each missing IP once, even when repeated in the batch
+	missing := map[string]int{}
+	missKeys := []string{}
+	missIPs := []net.IP{}
+	for i, k := range keys {
+		if k != "" {
+			g.counts.count(found[i])
+		}
+		if found[i] || k == "" {
+			continue
+		}
+		if _, pres := missing[k]; !pres {
+			missing[k] = len(missKeys)
+			missKeys = append(missKeys, k)
+			missIPs = append(missIPs, net.IP(ips[i].Unmap().AsSlice()))
+		}
+	}
+	var firstErr error
+	if len(missIPs) > 0 {
+		geos, errs := g.geolocateAll(missIPs)
+		missEntries := make([]CacheEntry, len(missIPs))
+		for i, geo := range geos {
+			if errs[i] != nil {
+				recordError(errs[i])
+				if firstErr == nil {
+					firstErr = errs[i]
+				}
+			}
+			missEntries[i] = g.withLifetime(newGeoEntry(geo, errs[i]))
+		}
+		// the lookups may have opened a new database
+		g.flushOnSwap()
+		setMany(g.cache, missKeys, missEntries)
+		for i, k := range keys {
+			if j, pres := missing[k]; pres && !found[i] {
+				entries[i] = missEntries[j]
+			}
+		}
+	}
+	return entries, keys, firstErr
+}
@@ -0,0 +1,20 @@
+package webgeo
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestLookupBatchE(t *testing.T) {
+	g := New(WithDBPath(brokenDB(t)), WithoutCache())
+	addrs := []netip.Addr{{}, netip.MustParseAddr("192.0.2.1")}
+	results, err := g.LookupBatchE(addrs)
+	var dbErr *DBError
+	if !errors.As(err, &dbErr) || len(results) != 2 || results[0].Country != "ZZ" || results[1].Country != "ZZ" {
+		t.Errorf("LookupBatchE = %+v, %v, want ZZ twice and a *DBError", results, err)
+	}
+	if m := g.CacheStats().Misses; m != 1 {
+		t.Errorf("%d lookups, want 1", m)
+	}
+}
@@ -0,0 +1,43 @@
+package webgeo
+
+import (
+	"log"
+	"net/http"
+)
+
+// DryRun makes the blocking and redirecting middlewares only log and
+// annotate the response (X-Webgeo-WouldBlock, X-Webgeo-WouldRedirect) with
+// what they would have done, so a policy can be validated against production
+// traffic before it is enforced.
+var DryRun = false
+
+// BlockCountries responds 403 Forbidden to visitors from the given country
+// codes or continents, the latter after ContinentPrefix (e.g.
+// "continent:AF"), and passes everybody else to next. Requests with
+// SkipMethods are only blocked when the location is already cached.
+func BlockCountries(next http.Handler, codes ...string) http.Handler {
+	return defaultGeolocator.BlockCountries(next, codes...)
+}
+
+func (g *Geolocator) BlockCountries(next http.Handler, codes ...string) http.Handler {
+	blocked := make(map[string]bool)
+	for _, c := range codes {
+		blocked[c] = true
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		AddVary(w.Header(), GeoVary)
+		res, ok := g.MiddlewareResolve(r)
+		if !ok || (!blocked[res.Country] && !blocked[ContinentPrefix+res.ContinentCode()]) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if DryRun {
+			log.Printf("webgeo: dry run: would block %s from %s", r.URL.Path, res.Country)
+			w.Header().Set("X-Webgeo-WouldBlock", "true")
+			next.ServeHTTP(w, r)
+			return
+		}
+		varyGeo(w.Header())
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	})
+}
@@ -0,0 +1,399 @@
+package webgeo
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"golang.org/x/text/language"
+)
+
+// CacheSize is the number of lookups kept by the package functions and by
+// Geolocators without WithCacheSize. The least recently used entry is
+// dropped to make room. 0 means unbounded, which lets a crawl of distinct
+// IPs grow the cache without limit.
+var CacheSize = 100000
+
+// CacheBytes caps the approximate memory of the in-memory cache, on top of
+// CacheSize, so it can be sized against a container memory limit. Entries
+// are counted by the strings and structures they hold, not by what the
+// runtime rounds them up to, so leave some headroom. The budget is split
+// over the shards of the cache and each keeps at least its most recent
+// entry, so a budget below about 64 entries is exceeded. 0 means no cap.
+var CacheBytes = 0
+
+// CacheTTL is how long a lookup stays cached, 0 means until it is dropped to
+// make room. IP ranges move between networks and countries, so entries
+// should not live much longer than the database they come from.
+var CacheTTL = 24 * time.Hour
+
+// CacheByPrefix caches lookups by /24 for IPv4 and /48 for IPv6 instead of
+// by IP. The databases rarely tell addresses of such networks apart, so the
+// hit rate for large consumer ISPs goes up a lot for a small loss of
+// precision, e.g. in City.
+var CacheByPrefix = false
+
+// NegativeCacheTTL is how long a failed lookup, or one of an IP without a
+// known country, stays cached. Short, so a broken database that gets fixed
+// or an IP range added by an update is picked up soon, but long enough that
+// repeated requests don't each pay for the failure. 0 or less means they
+// are not cached.
+var NegativeCacheTTL = time.Minute
+
+// Cache keeps lookups by IP, see WithCache. Implementations must be safe
+// for concurrent use and may drop entries at any time.
+type Cache interface {
+	Get(ip string) (CacheEntry, bool)
+	Set(ip string, e CacheEntry)
+	Delete(ip string)
+	Len() int
+}
+
+// the Cache of WithoutCache
+type noCache struct{}
+
+func (noCache) Get(ip string) (CacheEntry, bool) { return CacheEntry{}, false }
+func (noCache) Set(ip string, e CacheEntry)      {}
+func (noCache) Delete(ip string)                 {}
+func (noCache) Len() int                         { return 0 }
+
+// NewMemoryCache returns the in-memory cache used by default, an LRU of size
+// entries expiring after ttl, or after NegativeCacheTTL when Negative. 0
+// means unbounded and never, respectively.
+func NewMemoryCache(size int, ttl time.Duration) Cache {
+	return newLookupCache(func() int { return size }, func() int { return CacheBytes }, func(e CacheEntry) time.Duration {
+		return negativeLifetime(e, ttl, NegativeCacheTTL)
+	})
+}
+
+// The lifetime of e given the ttl of all entries and the one of Negative
+// entries, negative when e is not to be cached
+func negativeLifetime(e CacheEntry, ttl, negative time.Duration) time.Duration {
+	if !e.Negative() {
+		return ttl
+	}
+	if negative <= 0 {
+		return -1
+	}
+	if ttl > 0 && ttl < negative {
+		return ttl
+	}
+	return negative
+}
+
+// FlushCache drops all cached lookups of the package functions
+func FlushCache() {
+	defaultGeolocator.FlushCache()
+}
+
+// FlushCache drops all cached lookups. This happens by itself when a database
+// with another build time is opened. A Cache given to WithCache is flushed
+// when it has a Clear() method.
+func (g *Geolocator) FlushCache() {
+	if c, ok := g.cache.(interface{ Clear() }); ok {
+		c.Clear()
+	}
+}
+
+// CacheCounts tell how well the lookup cache works: a high miss rate with a
+// full cache and many evictions calls for a bigger one, or is a crawl or an
+// attack churning through IPs.
+type CacheCounts struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	// entries dropped to make room, only counted by the in-memory cache
+	Evictions uint64 `json:"evictions"`
+	Size      int    `json:"size"`
+	// approximate memory of the entries, only counted by the in-memory cache
+	Bytes int `json:"bytes"`
+}
+
+// CacheStats returns the counters of the cache of the package functions
+func CacheStats() CacheCounts {
+	return defaultGeolocator.CacheStats()
+}
+
+// CacheStats returns the counters of the cache of g since it was created
+func (g *Geolocator) CacheStats() CacheCounts {
+	c := CacheCounts{
+		Hits:   atomic.LoadUint64(&g.counts.hits),
+		Misses: atomic.LoadUint64(&g.counts.misses),
+		Size:   g.cache.Len(),
+	}
+	if lc, ok := g.cache.(*lookupCache); ok {
+		c.Evictions = atomic.LoadUint64(&lc.evictions)
+		c.Bytes = lc.bytes()
+	}
+	return c
+}
+
+// hits and misses of lookups, shared by the Geolocators sharing a cache
+type cacheCounts struct {
+	hits, misses uint64
+}
+
+func (c *cacheCounts) count(hit bool) {
+	if hit {
+		atomic.AddUint64(&c.hits, 1)
+	} else {
+		atomic.AddUint64(&c.misses, 1)
+	}
+}
+
+// Lookup results by IP, least recently used first out. The entries are
+// spread over shards by a hash of the IP, each with its own lock and LRU, so
+// concurrent requests rarely wait for each other.
+type lookupCache struct {
+	shards []*cacheShard
+	size   func() int                       // max entries, 0 is unbounded
+	budget func() int                       // max bytes, 0 is unbounded
+	ttl    func(e CacheEntry) time.Duration // 0 never expires, < 0 not cached
+
+	evictions uint64
+}
+
+// enough that 64 cores rarely contend, few enough for small caches
+const cacheShards = 64
+
+type cacheShard struct {
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // of *cacheItem, most recently used first
+	used    int        // bytes of the entries
+}
+
+type cacheItem struct {
+	ip      string
+	e       CacheEntry
+	expires time.Time // zero never expires
+	bytes   int
+}
+
+func newLookupCache(size, budget func() int, ttl func(e CacheEntry) time.Duration) *lookupCache {
+	return newShardedCache(cacheShards, size, budget, ttl)
+}
+
+func newShardedCache(n int, size, budget func() int, ttl func(e CacheEntry) time.Duration) *lookupCache {
+	c := &lookupCache{shards: make([]*cacheShard, n), size: size, budget: budget, ttl: ttl}
+	for i := range c.shards {
+		c.shards[i] = &cacheShard{entries: make(map[string]*list.Element), lru: list.New()}
+	}
+	return c
+}
+
+// the shard of ip, by its FNV-1a hash
+func (c *lookupCache) shard(ip string) *cacheShard {
+	h := uint32(2166136261)
+	for i := 0; i < len(ip); i++ {
+		h ^= uint32(ip[i])
+		h *= 16777619
+	}
+	return c.shards[h%uint32(len(c.shards))]
+}
+
+// max entries and bytes of a shard, 0 is unbounded. The cache as a whole
+// may hold a few more than size when it isn't a multiple of the shards, and
+// more than budget when a share is less than an entry, see setLocked.
+func (c *lookupCache) shardLimits() (size, budget int) {
+	if size = c.size(); size > 0 {
+		size = (size + len(c.shards) - 1) / len(c.shards)
+	}
+	if budget = c.budget(); budget > 0 {
+		budget = (budget + len(c.shards) - 1) / len(c.shards)
+	}
+	return size, budget
+}
+
+func (c *lookupCache) Get(ip string) (CacheEntry, bool) {
+	s := c.shard(ip)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.getLocked(ip)
+}
+
+func (s *cacheShard) getLocked(ip string) (CacheEntry, bool) {
+	el, pres := s.entries[ip]
+	if !pres {
+		return CacheEntry{}, false
+	}
+	item := el.Value.(*cacheItem)
+	if !item.expires.IsZero() && time.Now().After(item.expires) {
+		s.removeLocked(el)
+		return CacheEntry{}, false
+	}
+	s.lru.MoveToFront(el)
+	return item.e, true
+}
+
+func (c *lookupCache) Set(ip string, e CacheEntry) {
+	s := c.shard(ip)
+	size, budget := c.shardLimits()
+	s.mutex.Lock()
+	c.setLocked(s, ip, e, size, budget)
+	s.mutex.Unlock()
+}
+
+func (c *lookupCache) setLocked(s *cacheShard, ip string, e CacheEntry, size, budget int) {
+	var expires time.Time
+	ttl := c.ttl(e)
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+	el, pres := s.entries[ip]
+	if ttl < 0 {
+		if pres {
+			s.removeLocked(el)
+		}
+		return
+	}
+	bytes := e.bytes(ip)
+	if pres {
+		item := el.Value.(*cacheItem)
+		s.used += bytes - item.bytes
+		item.e, item.expires, item.bytes = e, expires, bytes
+		s.lru.MoveToFront(el)
+	} else {
+		s.entries[ip] = s.lru.PushFront(&cacheItem{ip, e, expires, bytes})
+		s.used += bytes
+	}
+	// the entry just set stays, even when it alone is over budget
+	for s.lru.Len() > 1 && (size > 0 && s.lru.Len() > size || budget > 0 && s.used > budget) {
+		s.removeLocked(s.lru.Back())
+		atomic.AddUint64(&c.evictions, 1)
+	}
+}
+
+func (c *lookupCache) Delete(ip string) {
+	s := c.shard(ip)
+	s.mutex.Lock()
+	if el, pres := s.entries[ip]; pres {
+		s.removeLocked(el)
+	}
+	s.mutex.Unlock()
+}
+
+func (s *cacheShard) removeLocked(el *list.Element) {
+	item := el.Value.(*cacheItem)
+	s.lru.Remove(el)
+	delete(s.entries, item.ip)
+	s.used -= item.bytes
+}
+
+// Get for many IPs, locking each shard once with the default cache
+func getMany(c Cache, ips []string) ([]CacheEntry, []bool) {
+	entries := make([]CacheEntry, len(ips))
+	found := make([]bool, len(ips))
+	if lc, ok := c.(*lookupCache); ok {
+		for s, idx := range lc.byShard(ips) {
+			s.mutex.Lock()
+			for _, i := range idx {
+				entries[i], found[i] = s.getLocked(ips[i])
+			}
+			s.mutex.Unlock()
+		}
+		return entries, found
+	}
+	for i, ip := range ips {
+		entries[i], found[i] = c.Get(ip)
+	}
+	return entries, found
+}
+
+// Set for many IPs, locking each shard once with the default cache
+func setMany(c Cache, ips []string, entries []CacheEntry) {
+	if lc, ok := c.(*lookupCache); ok {
+		size, budget := lc.shardLimits()
+		for s, idx := range lc.byShard(ips) {
+			s.mutex.Lock()
+			for _, i := range idx {
+				lc.setLocked(s, ips[i], entries[i], size, budget)
+			}
+			s.mutex.Unlock()
+		}
+		return
+	}
+	for i, ip := range ips {
+		c.Set(ip, entries[i])
+	}
+}
+
+// indexes of ips by shard
+func (c *lookupCache) byShard(ips []string) map[*cacheShard][]int {
+	m := make(map[*cacheShard][]int)
+	for i, ip := range ips {
+		s := c.shard(ip)
+		m[s] = append(m[s], i)
+	}
+	return m
+}
+
+func (c *lookupCache) Len() int {
+	n := 0
+	for _, s := range c.shards {
+		s.mutex.Lock()
+		n += s.lru.Len()
+		s.mutex.Unlock()
+	}
+	return n
+}
+
+func (c *lookupCache) bytes() int {
+	n := 0
+	for _, s := range c.shards {
+		s.mutex.Lock()
+		n += s.used
+		s.mutex.Unlock()
+	}
+	return n
+}
+
+// Call f for every unexpired entry, most recently used first within each
+// shard, locking one shard at a time
+func (c *lookupCache) Range(f func(ip string, e CacheEntry)) {
+	now := time.Now()
+	for _, s := range c.shards {
+		s.mutex.Lock()
+		for el := s.lru.Front(); el != nil; el = el.Next() {
+			if item := el.Value.(*cacheItem); item.expires.IsZero() || now.Before(item.expires) {
+				f(item.ip, item.e)
+			}
+		}
+		s.mutex.Unlock()
+	}
+}
+
+func (c *lookupCache) Clear() {
+	for _, s := range c.shards {
+		s.mutex.Lock()
+		s.entries = make(map[string]*list.Element)
+		s.lru.Init()
+		s.used = 0
+		s.mutex.Unlock()
+	}
+}
+
+// bookkeeping of an entry: the cacheItem, its list element and map slot
+const cacheItemOverhead = int(unsafe.Sizeof(cacheItem{})+unsafe.Sizeof(list.Element{})) + 64
+
+// Approximate bytes of e cached under ip: the structures and strings it
+// holds, without allocator rounding
+func (e CacheEntry) bytes(ip string) int {
+	n := cacheItemOverhead + 2*len(ip) + len(e.langs)*int(unsafe.Sizeof(language.Tag{}))
+	geo := e.Geo
+	if geo == nil {
+		return n
+	}
+	n += int(unsafe.Sizeof(*geo)) + len(geo.Ip) + len(geo.Cc) + len(geo.Country) +
+		len(geo.City) + len(geo.TimeZone) + len(geo.ContinentCode) + len(geo.Continent) +
+		len(geo.RegisteredCountry) + len(geo.RepresentedCountry) + len(geo.AutonomousSystemOrganization) +
+		len(geo.MostSpecificSubdivision.IsoCode) + len(geo.MostSpecificSubdivision.Name)
+	for _, sub := range geo.Subdivisions {
+		n += int(unsafe.Sizeof(sub)) + len(sub.IsoCode) + len(sub.Name)
+	}
+	for _, w := range geo.Warnings {
+		n += int(unsafe.Sizeof(w)) + len(w)
+	}
+	return n
+}
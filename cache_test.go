@@ -0,0 +1,172 @@
+package webgeo
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// go test -bench Cache -cpu 1,8,64
+
+func benchmarkCache(b *testing.B, shards int) {
+	c := newShardedCache(shards, func() int { return 100000 }, func() int { return 0 }, func(CacheEntry) time.Duration { return time.Hour })
+	e := newGeoEntry(&GeoRecord{Cc: "DE"}, nil)
+	ips := make([]string, 10000)
+	for i := range ips {
+		ips[i] = "10.0." + strconv.Itoa(i/256) + "." + strconv.Itoa(i%256)
+		c.Set(ips[i], e)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			ip := ips[i%len(ips)]
+			if _, ok := c.Get(ip); !ok || i%10 == 0 {
+				c.Set(ip, e)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkCacheSingleLock(b *testing.B) { benchmarkCache(b, 1) }
+func BenchmarkCacheSharded(b *testing.B)    { benchmarkCache(b, cacheShards) }
+
+func TestCacheSize(t *testing.T) {
+	c := newShardedCache(1, func() int { return 2 }, func() int { return 0 }, func(CacheEntry) time.Duration { return 0 })
+	e := newGeoEntry(&GeoRecord{Cc: "DE"}, nil)
+	c.Set("10.0.0.1", e)
+	c.Set("10.0.0.2", e)
+	c.Get("10.0.0.1")
+	c.Set("10.0.0.3", e)
+	if _, ok := c.Get("10.0.0.2"); ok {
+		t.Errorf("least recently used entry not evicted")
+	}
+	if _, ok := c.Get("10.0.0.1"); !ok {
+		t.Errorf("recently used entry evicted")
+	}
+	if c.Len() != 2 || c.evictions != 1 {
+		t.Errorf("Len %d, evictions %d, want 2 and 1", c.Len(), c.evictions)
+	}
+}
+
+func TestCacheBudget(t *testing.T) {
+	e := newGeoEntry(&GeoRecord{Cc: "DE", Country: "Germany", City: "Berlin"}, nil)
+	one := e.bytes("10.0.0.1")
+	c := newShardedCache(1, func() int { return 0 }, func() int { return 3 * one }, func(CacheEntry) time.Duration { return 0 })
+	for i := 0; i < 10; i++ {
+		c.Set("10.0.0."+strconv.Itoa(i), e)
+	}
+	if c.Len() != 3 || c.bytes() > 3*one {
+		t.Errorf("Len %d, bytes %d, want 3 entries within %d bytes", c.Len(), c.bytes(), 3*one)
+	}
+	if _, ok := c.Get("10.0.0.9"); !ok {
+		t.Errorf("newest entry evicted")
+	}
+
+	// a share of less than an entry per shard still caches
+	c = newShardedCache(cacheShards, func() int { return 0 }, func() int { return one }, func(CacheEntry) time.Duration { return 0 })
+	for i := 0; i < 10; i++ {
+		ip := "10.0.0." + strconv.Itoa(i)
+		c.Set(ip, e)
+		if _, ok := c.Get(ip); !ok {
+			t.Fatalf("%s evicted right after Set with a small budget", ip)
+		}
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	c := newShardedCache(1, func() int { return 0 }, func() int { return 0 }, func(e CacheEntry) time.Duration {
+		return negativeLifetime(e, time.Hour, 20*time.Millisecond)
+	})
+	c.Set("10.0.0.1", newGeoEntry(&GeoRecord{Cc: "DE"}, nil))
+	c.Set("10.0.0.2", newGeoEntry(&GeoRecord{}, nil))
+	if _, ok := c.Get("10.0.0.2"); !ok {
+		t.Fatalf("negative entry not cached")
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, ok := c.Get("10.0.0.2"); ok {
+		t.Errorf("negative entry not expired")
+	}
+	if _, ok := c.Get("10.0.0.1"); !ok {
+		t.Errorf("positive entry expired")
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len %d after expiry, want 1", c.Len())
+	}
+
+	// negative lifetime below 0: not cached, and an older entry is dropped
+	c = newShardedCache(1, func() int { return 0 }, func() int { return 0 }, func(e CacheEntry) time.Duration {
+		return negativeLifetime(e, time.Hour, -1)
+	})
+	c.Set("10.0.0.1", newGeoEntry(&GeoRecord{Cc: "DE"}, nil))
+	c.Set("10.0.0.1", newGeoEntry(nil, ErrNoClientIP))
+	if _, ok := c.Get("10.0.0.1"); ok {
+		t.Errorf("negative entry cached with a negative lifetime")
+	}
+}
+
+// a Cache recording its keys
+type mapCache struct {
+	m    map[string]CacheEntry
+	sets []string
+}
+
+func (c *mapCache) Get(ip string) (CacheEntry, bool) { e, ok := c.m[ip]; return e, ok }
+func (c *mapCache) Set(ip string, e CacheEntry)      { c.m[ip] = e; c.sets = append(c.sets, ip) }
+func (c *mapCache) Delete(ip string)                 { delete(c.m, ip) }
+func (c *mapCache) Len() int                         { return len(c.m) }
+
+func TestCustomCache(t *testing.T) {
+	c := &mapCache{m: map[string]CacheEntry{}}
+	g := New(WithDBPath(brokenDB(t)), WithCache(c))
+	g.lookupEntry("192.0.2.1")
+	g.lookupEntry("192.0.2.1")
+	if len(c.sets) != 1 || c.sets[0] != "192.0.2.1" {
+		t.Errorf("Set calls %v, want one for 192.0.2.1", c.sets)
+	}
+	if s := g.CacheStats(); s.Hits != 1 || s.Misses != 1 || s.Size != 1 {
+		t.Errorf("CacheStats %+v", s)
+	}
+	if e := g.lookupEntry("192.0.2.1"); e.Err == nil {
+		t.Errorf("cached failure lost its error")
+	}
+
+	// entries of a shared cache come back from another Geolocator
+	c.m["192.0.2.2"] = newGeoEntry(&GeoRecord{Ip: "192.0.2.2", Cc: "DE"}, nil)
+	if e := New(WithDBPath(brokenDB(t)), WithCache(c)).lookupEntry("192.0.2.2"); e.country != "DE" || len(e.langs) == 0 {
+		t.Errorf("entry from the shared cache %+v, want DE with languages", e)
+	}
+}
+
+func TestCacheByPrefixKeys(t *testing.T) {
+	c := &mapCache{m: map[string]CacheEntry{}}
+	g := New(WithDBPath(brokenDB(t)), WithCache(c), WithCacheByPrefix(true))
+	c.m["192.0.2.0/24"] = newGeoEntry(&GeoRecord{Ip: "192.0.2.1", Cc: "DE"}, nil)
+	e := g.lookupEntry("192.0.2.77")
+	if e.Geo == nil || e.Geo.Cc != "DE" || e.Geo.Ip != "192.0.2.77" {
+		t.Errorf("network entry %+v, want DE for 192.0.2.77", e.Geo)
+	}
+	if c.m["192.0.2.0/24"].Geo.Ip != "192.0.2.1" {
+		t.Errorf("cached record modified")
+	}
+	for ip, want := range map[string]string{
+		"192.0.2.77":       "192.0.2.0/24",
+		"::ffff:192.0.2.1": "192.0.2.0/24",
+		"2001:db8:1:2::1":  "2001:db8:1::/48",
+		"junk":             "junk",
+	} {
+		if got := g.cacheKey(ip); got != want {
+			t.Errorf("cacheKey(%s) = %s, want %s", ip, got, want)
+		}
+	}
+}
+
+func TestWithoutCache(t *testing.T) {
+	g := New(WithDBPath(brokenDB(t)), WithoutCache())
+	g.lookupEntry("192.0.2.1")
+	g.lookupEntry("192.0.2.1")
+	if s := g.CacheStats(); s.Hits != 0 || s.Misses != 2 || s.Size != 0 {
+		t.Errorf("CacheStats %+v, want 2 misses", s)
+	}
+}
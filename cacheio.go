@@ -0,0 +1,181 @@
+package webgeo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/netip"
+	"os"
+	"time"
+)
+
+const cacheFormatVersion = 1
+
+type cacheHeader struct {
+	Version int `json:"webgeo_cache"`
+}
+
+type cacheLine struct {
+	Ip  string     `json:"ip"`
+	Geo *GeoRecord `json:"geo"`
+	// time of the lookup, missing in exports of older versions
+	At *time.Time `json:"at,omitempty"`
+}
+
+// ExportCache writes the successful lookups in the cache as JSON lines: a
+// {"webgeo_cache":1} header followed by one {"ip":..., "geo":{...}} object
+// per entry. The format only changes with the version number, so the
+// outgoing deployment of a blue-green switch can hand its warm cache to the
+// incoming one. A Cache given to WithCache needs a
+// Range(func(ip string, e CacheEntry)) method to be exported.
+func ExportCache(w io.Writer) error {
+	return defaultGeolocator.ExportCache(w)
+}
+
+func (g *Geolocator) ExportCache(w io.Writer) error {
+	g.flushOnSwap()
+	c, ok := g.cache.(interface {
+		Range(f func(ip string, e CacheEntry))
+	})
+	if !ok {
+		return fmt.Errorf("Cannot export cache %T", g.cache)
+	}
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	if err := enc.Encode(cacheHeader{cacheFormatVersion}); err != nil {
+		return err
+	}
+	lines := []cacheLine{}
+	c.Range(func(ip string, e CacheEntry) {
+		if e.Geo != nil {
+			l := cacheLine{Ip: ip, Geo: e.Geo}
+			if !e.at.IsZero() {
+				at := e.at
+				l.At = &at
+			}
+			lines = append(lines, l)
+		}
+	})
+	// least recently used first, so an import ends up in the same order
+	for i := len(lines) - 1; i >= 0; i-- {
+		if err := enc.Encode(lines[i]); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// ImportCache loads entries written by ExportCache into the cache and
+// returns how many were imported.
+func ImportCache(r io.Reader) (int, error) {
+	return defaultGeolocator.ImportCache(r)
+}
+
+func (g *Geolocator) ImportCache(r io.Reader) (int, error) {
+	return g.importCache(r, 0, false)
+}
+
+// SaveCache writes the cache to the file at path with ExportCache, so a
+// short-lived process can hand it to the next one, see RestoreCache. The
+// file is replaced atomically.
+func SaveCache(path string) error {
+	return defaultGeolocator.SaveCache(path)
+}
+
+func (g *Geolocator) SaveCache(path string) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := g.ExportCache(f); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// RestoreCache loads the entries saved by SaveCache at path that were looked
+// up less than maxAge ago, 0 meaning any age, and returns how many were
+// loaded. Corrupt or truncated lines are skipped, so a damaged file costs
+// only the entries on those lines. A missing file is not an error.
+func RestoreCache(path string, maxAge time.Duration) (int, error) {
+	return defaultGeolocator.RestoreCache(path, maxAge)
+}
+
+func (g *Geolocator) RestoreCache(path string, maxAge time.Duration) (int, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	n, err := g.importCache(f, maxAge, true)
+	if err != nil {
+		return n, fmt.Errorf("Could not restore cache %s: %v", path, err)
+	}
+	return n, nil
+}
+
+// Read an export line by line. With skipBad, lines that don't decode are
+// logged and skipped instead of ending the import.
+func (g *Geolocator) importCache(r io.Reader, maxAge time.Duration, skipBad bool) (int, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return 0, err
+		}
+		return 0, fmt.Errorf("Invalid cache export header: empty input")
+	}
+	var h cacheHeader
+	if err := json.Unmarshal(sc.Bytes(), &h); err != nil {
+		return 0, fmt.Errorf("Invalid cache export header: %v", err)
+	}
+	if h.Version != cacheFormatVersion {
+		return 0, fmt.Errorf("Unsupported cache export version %d", h.Version)
+	}
+	n, bad := 0, 0
+	for lineNo := 2; sc.Scan(); lineNo++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var l cacheLine
+		if err := json.Unmarshal(sc.Bytes(), &l); err != nil {
+			if !skipBad {
+				return n, fmt.Errorf("Line %d: %v", lineNo, err)
+			}
+			bad++
+			continue
+		}
+		if _, err := netip.ParsePrefix(l.Ip); l.Geo == nil || (remoteIP(l.Ip) == "" && err != nil) {
+			continue
+		}
+		e := newGeoEntry(l.Geo, nil)
+		if l.At != nil {
+			e.at = *l.At
+		}
+		if maxAge > 0 && (l.At == nil || time.Since(*l.At) > maxAge) {
+			continue
+		}
+		g.cache.Set(l.Ip, g.withLifetime(e))
+		n++
+	}
+	if bad > 0 {
+		log.Printf("webgeo: skipped %d corrupt cache lines", bad)
+	}
+	if err := sc.Err(); err != nil && !skipBad {
+		return n, err
+	}
+	return n, nil
+}
@@ -0,0 +1,45 @@
+package webgeo
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExportImportCache(t *testing.T) {
+	src := New(WithDBPath(brokenDB(t)))
+	src.cache.Set("192.0.2.1", newGeoEntry(&GeoRecord{Ip: "192.0.2.1", Cc: "DE"}, nil))
+	src.cache.Set("192.0.2.2", newGeoEntry(nil, ErrNoClientIP)) // failures aren't exported
+	var buf bytes.Buffer
+	if err := src.ExportCache(&buf); err != nil {
+		t.Fatal(err)
+	}
+	dst := New(WithDBPath(brokenDB(t)))
+	if n, err := dst.ImportCache(&buf); n != 1 || err != nil {
+		t.Fatalf("ImportCache = %d, %v, want 1", n, err)
+	}
+	if geo, err := dst.LookupCtx(context.Background(), "192.0.2.1"); err != nil || geo == nil || geo.Cc != "DE" {
+		t.Errorf("LookupCtx after import = %v, %v", geo, err)
+	}
+	if _, ok := defaultGeolocator.cache.Get("192.0.2.1"); ok {
+		t.Errorf("import went to the package cache")
+	}
+}
+
+func TestSaveRestoreCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.jsonl")
+	src := New(WithDBPath(brokenDB(t)))
+	src.cache.Set("192.0.2.1", newGeoEntry(&GeoRecord{Ip: "192.0.2.1", Cc: "DE"}, nil))
+	if err := src.SaveCache(path); err != nil {
+		t.Fatal(err)
+	}
+	dst := New(WithDBPath(brokenDB(t)))
+	if n, err := dst.RestoreCache(path, time.Hour); n != 1 || err != nil {
+		t.Errorf("RestoreCache = %d, %v, want 1", n, err)
+	}
+	if n, err := dst.RestoreCache(path+".missing", 0); n != 0 || err != nil {
+		t.Errorf("RestoreCache of a missing file = %d, %v", n, err)
+	}
+}
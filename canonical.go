@@ -0,0 +1,79 @@
+package webgeo
+
+import "golang.org/x/text/language"
+
+// Parse lang into the canonical form of the tags emitted by the package.
+// language.Parse already replaces deprecated and grandfathered tags (iw is
+// he, i-klingon is tlh), canonical also drops redundant scripts.
+func parseLang(lang string) (language.Tag, error) {
+	t, err := language.Parse(lang)
+	if err != nil {
+		return language.Und, err
+	}
+	return canonical(t), nil
+}
+
+// en-Latn-US is en-US
+func canonical(t language.Tag) language.Tag {
+	if c, err := language.BCP47.Canonicalize(t); err == nil {
+		return c
+	}
+	return t
+}
+
+func canonicalTags(tags []language.Tag) []language.Tag {
+	l := make([]language.Tag, len(tags))
+	for i, t := range tags {
+		l[i] = canonical(t)
+	}
+	return l
+}
+
+// canonical form of the valid ones of langs
+func canonicalLangs(langs []string) []string {
+	l := []string{}
+	for _, lang := range langs {
+		if t, err := parseLang(lang); err == nil {
+			l = append(l, t.String())
+		}
+	}
+	return l
+}
+
+// languages commonly written in more than one script, see withScript
+var scriptLangs = map[string]bool{
+	"az": true, "bs": true, "ku": true, "mn": true, "ms": true,
+	"pa": true, "sr": true, "uz": true, "zh": true,
+}
+
+// Add the script used in country cc to tags of scriptLangs that have none,
+// e.g. zh-TW is zh-Hant-TW and zh for CN is zh-Hans, so templates can pick
+// Simplified or Traditional Chinese.
+func withScript(t language.Tag, cc string) language.Tag {
+	base, _ := t.Base()
+	if !scriptLangs[base.String()] {
+		return t
+	}
+	if _, conf := t.Script(); conf == language.Exact {
+		return t
+	}
+	region, conf := t.Region()
+	regional := conf == language.Exact
+	if !regional {
+		var err error
+		if region, err = language.ParseRegion(cc); err != nil {
+			return t
+		}
+	}
+	likely, err := language.Compose(base, region)
+	if err != nil {
+		return t
+	}
+	script, _ := likely.Script()
+	if regional {
+		t, _ = language.Compose(base, script, region)
+	} else {
+		t, _ = language.Compose(base, script)
+	}
+	return t
+}
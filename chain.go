@@ -0,0 +1,66 @@
+package webgeo
+
+import (
+	"golang.org/x/text/language"
+)
+
+// FallbackChain returns tag followed by its CLDR parents and the fallback
+// language, e.g. de-AT, de, en or es-MX, es-419, es, en. Bundles with partial
+// translations resolve each message along the chain. The fallback language
+// is the one of WithFallbackLang, English by default.
+func FallbackChain(tag language.Tag) []language.Tag {
+	return defaultGeolocator.FallbackChain(tag)
+}
+
+func (g *Geolocator) FallbackChain(tag language.Tag) []language.Tag {
+	chain := parentChain(tag)
+	fallback := g.chainFallback()
+	for _, t := range chain {
+		if t == fallback {
+			return chain
+		}
+	}
+	return append(chain, fallback)
+}
+
+// tag and its parents, without the fallback language
+func parentChain(tag language.Tag) []language.Tag {
+	chain := []language.Tag{}
+	for t := canonical(tag); t != language.Und; t = canonical(t.Parent()) {
+		chain = append(chain, t)
+	}
+	return chain
+}
+
+func (g *Geolocator) chainFallback() language.Tag {
+	if g.fallbackLang != language.Und {
+		return canonical(g.fallbackLang)
+	}
+	return language.English
+}
+
+// Replace each language by its fallback chain. The parents keep the source
+// of the language they come from, the fallback language goes last.
+func (g *Geolocator) expandChains(langs []string, sources map[string]Source) []string {
+	expanded := []string{}
+	for _, l := range langs {
+		t, err := language.Parse(l)
+		if err != nil {
+			expanded = append(expanded, l)
+			continue
+		}
+		for _, p := range parentChain(t) {
+			if s := p.String(); !contains(expanded, s) {
+				expanded = append(expanded, s)
+				if _, pres := sources[s]; !pres {
+					sources[s] = sources[l]
+				}
+			}
+		}
+	}
+	if fallback := g.chainFallback().String(); !contains(expanded, fallback) {
+		expanded = append(expanded, fallback)
+		sources[fallback] = SourceFallback
+	}
+	return expanded
+}
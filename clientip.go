@@ -0,0 +1,92 @@
+package webgeo
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// TrustedProxies are the networks of the reverse proxies and load balancers
+// in front of the application. Forwarding headers are only believed when they
+// were appended by one of them.
+var TrustedProxies = []netip.Prefix{}
+
+// ClientIPFunc extracts the client IP used for geolocation from the request.
+// It returns "" when there is no usable IP.
+var ClientIPFunc = RightmostTrustedIP
+
+// RemoteAddrIP ignores forwarding headers and uses the peer address only
+func RemoteAddrIP(r *http.Request) string {
+	return remoteIP(r.RemoteAddr)
+}
+
+// RightmostTrustedIP walks X-Forwarded-For from the right, skipping
+// TrustedProxies, and returns the first address that is not trusted. Entries
+// to the left of it were supplied by the client and can't be believed.
+// With no TrustedProxies configured this is the same as RemoteAddrIP.
+func RightmostTrustedIP(r *http.Request) string {
+	return rightmostTrustedIP(r, TrustedProxies)
+}
+
+func rightmostTrustedIP(r *http.Request, proxies []netip.Prefix) string {
+	ip := remoteIP(r.RemoteAddr)
+	if ip == "" || !isTrustedProxy(ip, proxies) {
+		return ip
+	}
+	hops := []string{}
+	for _, h := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(h, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := remoteIP(strings.TrimSpace(hops[i]))
+		if hop == "" {
+			// garbage in the chain, nothing left of it can be trusted
+			return ip
+		}
+		ip = hop
+		if !isTrustedProxy(ip, proxies) {
+			return ip
+		}
+	}
+	// every hop is a trusted proxy, use the leftmost one
+	return ip
+}
+
+func isTrustedProxy(ipS string, proxies []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ipS)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range proxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePrefixes parses CIDRs or single IPs, e.g. for TrustedProxies:
+//
+//	webgeo.TrustedProxies, err = webgeo.ParsePrefixes("10.0.0.0/8", "192.0.2.10")
+func ParsePrefixes(s ...string) ([]netip.Prefix, error) {
+	prefixes := []netip.Prefix{}
+	for _, e := range s {
+		e = strings.TrimSpace(e)
+		if strings.Contains(e, "/") {
+			p, err := netip.ParsePrefix(e)
+			if err != nil {
+				return nil, err
+			}
+			prefixes = append(prefixes, p.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(e)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid IP or CIDR %q", e)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
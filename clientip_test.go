@@ -0,0 +1,88 @@
+package webgeo
+
+import (
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestRemoteIP(t *testing.T) {
+	tests := map[string]string{
+		"192.0.2.1:4321":       "192.0.2.1",
+		"192.0.2.1":            "192.0.2.1",
+		"[2001:db8::1]:4321":   "2001:db8::1",
+		"2001:db8::1":          "2001:db8::1",
+		"[2001:db8::1]":        "2001:db8::1",
+		"":                     "",
+		"@":                    "",
+		"example.com:80":       "",
+		"192.0.2.1:4321:extra": "",
+	}
+	for addr, want := range tests {
+		if got := remoteIP(addr); got != want {
+			t.Errorf("remoteIP(%q) = %q, want %q", addr, got, want)
+		}
+	}
+}
+
+func TestRightmostTrustedIP(t *testing.T) {
+	proxies, err := ParsePrefixes("10.0.0.0/8", "192.0.2.10", "2001:db8:ffff::/48")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        []string
+		want       string
+	}{
+		{"direct", "198.51.100.1:1", nil, "198.51.100.1"},
+		{"untrusted peer ignores header", "198.51.100.1:1", []string{"203.0.113.9"}, "198.51.100.1"},
+		{"one proxy", "10.0.0.1:1", []string{"203.0.113.9"}, "203.0.113.9"},
+		{"spoofed left entries", "10.0.0.1:1", []string{"1.2.3.4, 203.0.113.9"}, "203.0.113.9"},
+		{"proxy chain", "10.0.0.1:1", []string{"203.0.113.9, 192.0.2.10, 10.1.1.1"}, "203.0.113.9"},
+		{"several headers", "10.0.0.1:1", []string{"1.2.3.4", "203.0.113.9, 10.1.1.1"}, "203.0.113.9"},
+		{"garbage stops the walk", "10.0.0.1:1", []string{"203.0.113.9, junk, 10.1.1.1"}, "10.1.1.1"},
+		{"ports and brackets", "10.0.0.1:1", []string{"[2001:db8::5]:443"}, "2001:db8::5"},
+		{"all trusted", "10.0.0.1:1", []string{"10.2.2.2, 10.1.1.1"}, "10.2.2.2"},
+		{"empty header", "10.0.0.1:1", []string{""}, "10.0.0.1"},
+		{"mapped proxy", "[::ffff:10.0.0.1]:1", []string{"203.0.113.9"}, "203.0.113.9"},
+		{"ipv6 proxy", "[2001:db8:ffff::1]:1", []string{"203.0.113.9"}, "203.0.113.9"},
+		{"no remote addr", "@", []string{"203.0.113.9"}, ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = tt.remoteAddr
+		for _, h := range tt.xff {
+			r.Header.Add("X-Forwarded-For", h)
+		}
+		if got := rightmostTrustedIP(r, proxies); got != tt.want {
+			t.Errorf("%s: %q, want %q", tt.name, got, tt.want)
+		}
+		if got := rightmostTrustedIP(r, nil); got != remoteIP(tt.remoteAddr) {
+			t.Errorf("%s without proxies: %q, want RemoteAddr", tt.name, got)
+		}
+	}
+}
+
+func TestParsePrefixes(t *testing.T) {
+	got, err := ParsePrefixes(" 10.0.0.1/8", "192.0.2.10", "2001:db8::1")
+	want := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.0.2.10/32"),
+		netip.MustParsePrefix("2001:db8::1/128"),
+	}
+	if err != nil || len(got) != len(want) {
+		t.Fatalf("ParsePrefixes = %v, %v", got, err)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("prefix %d: %s, want %s", i, got[i], want[i])
+		}
+	}
+	for _, bad := range []string{"10.0.0.0/33", "example.com", ""} {
+		if _, err := ParsePrefixes(bad); err == nil {
+			t.Errorf("ParsePrefixes(%q): no error", bad)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seckiss/webgeo"
+)
+
+func bench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	ipsFile := fs.String("ips", "", "file with one client IP per line, e.g. extracted from access logs")
+	db := fs.String("db", webgeo.DBPath, "database path")
+	mode := fs.String("mode", "mmap", "database access mode: mmap or memory")
+	warmFile := fs.String("warm", "", "file with one IP or network (81.2.69.0/24) per line to look up before the cold pass")
+	fs.Parse(args)
+	if *ipsFile == "" {
+		return fmt.Errorf("--ips is required")
+	}
+	if *mode != "mmap" && *mode != "memory" {
+		return fmt.Errorf("unknown --mode %q", *mode)
+	}
+	ips, err := readLines(*ipsFile)
+	if err != nil {
+		return err
+	}
+	if len(ips) == 0 {
+		return fmt.Errorf("no IPs in %s", *ipsFile)
+	}
+	webgeo.DBPath = *db
+	webgeo.DBInMemory = *mode == "memory"
+
+	reqs := make([]*http.Request, len(ips))
+	unique := make(map[string]bool)
+	for i, ip := range ips {
+		reqs[i] = &http.Request{RemoteAddr: ip, Header: http.Header{}}
+		unique[ip] = true
+	}
+
+	if *warmFile != "" {
+		warm, err := readAddrs(*warmFile)
+		if err != nil {
+			return err
+		}
+		start := time.Now()
+		if err := webgeo.Warm(warm); err != nil {
+			return err
+		}
+		fmt.Printf("warmed %d IPs in %v\n", len(warm), time.Since(start))
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	statsBefore := webgeo.CacheStats()
+
+	cold := run(reqs)
+	stats := webgeo.CacheStats()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+
+	warm := run(reqs)
+
+	fmt.Printf("%d lookups, %d unique IPs, db %s, mode %s\n\n", len(ips), len(unique), *db, *mode)
+	fmt.Printf("      %10s %10s %10s %10s %10s %12s\n", "mean", "p50", "p90", "p99", "max", "lookups/s")
+	report("cold", cold)
+	report("warm", warm)
+	hits, misses := stats.Hits-statsBefore.Hits, stats.Misses-statsBefore.Misses
+	rate := 0.0
+	if hits+misses > 0 {
+		rate = 100 * float64(hits) / float64(hits+misses)
+	}
+	fmt.Printf("\ncache hit rate (cold pass): %.1f%% (%d hits, %d misses, %d evictions)\n",
+		rate, hits, misses, stats.Evictions-statsBefore.Evictions)
+	fmt.Printf("heap after cold pass: %+.1f MiB (%.1f MiB in use, %.1f MiB from OS)\n",
+		mib(int64(after.HeapAlloc)-int64(before.HeapAlloc)), mib(int64(after.HeapAlloc)), mib(int64(after.Sys)))
+	if *mode == "mmap" {
+		fmt.Printf("note: mmapped database pages are not part of the heap\n")
+	}
+	return nil
+}
+
+func run(reqs []*http.Request) []time.Duration {
+	d := make([]time.Duration, len(reqs))
+	for i, r := range reqs {
+		start := time.Now()
+		webgeo.Resolve(r)
+		d[i] = time.Since(start)
+	}
+	return d
+}
+
+func report(name string, d []time.Duration) {
+	var total time.Duration
+	for _, e := range d {
+		total += e
+	}
+	sorted := append([]time.Duration{}, d...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	pct := func(p float64) time.Duration {
+		return sorted[int(p*float64(len(sorted)-1))]
+	}
+	fmt.Printf("%-5s %10v %10v %10v %10v %10v %12.0f\n", name,
+		total/time.Duration(len(d)), pct(0.5), pct(0.9), pct(0.99), sorted[len(sorted)-1],
+		float64(len(d))/total.Seconds())
+}
+
+func mib(b int64) float64 {
+	return float64(b) / (1 << 20)
+}
+
+// IPs and networks, a network standing for its first address, which is
+// enough for webgeo.CacheByPrefix
+func readAddrs(path string) ([]netip.Addr, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]netip.Addr, len(lines))
+	for i, l := range lines {
+		if prefix, err := netip.ParsePrefix(l); err == nil {
+			addrs[i] = prefix.Masked().Addr()
+		} else if addrs[i], err = netip.ParseAddr(l); err != nil {
+			return nil, fmt.Errorf("%s: invalid IP or network %q", path, l)
+		}
+	}
+	return addrs, nil
+}
+
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	lines := []string{}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if l := strings.TrimSpace(s.Text()); l != "" && !strings.HasPrefix(l, "#") {
+			lines = append(lines, l)
+		}
+	}
+	return lines, s.Err()
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/seckiss/webgeo"
+)
+
+func countries(args []string) error {
+	if len(args) == 0 || args[0] != "export" {
+		return fmt.Errorf("usage: webgeo countries export [--format json|csv|yaml] [--out file]")
+	}
+	fs := flag.NewFlagSet("countries export", flag.ExitOnError)
+	format := fs.String("format", "json", "json, csv or yaml")
+	out := fs.String("out", "-", "output file, - for stdout")
+	fs.Parse(args[1:])
+	if *out == "-" {
+		return webgeo.ExportCountries(os.Stdout, *format)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := webgeo.ExportCountries(f, *format); err != nil {
+		f.Close()
+		os.Remove(*out)
+		return err
+	}
+	return f.Close()
+}
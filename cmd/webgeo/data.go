@@ -0,0 +1,31 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/seckiss/webgeo"
+)
+
+func data(args []string) error {
+	fs := flag.NewFlagSet("data", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the manifest as JSON")
+	fs.Parse(args)
+	manifest := webgeo.DataManifest()
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(manifest)
+	}
+	fmt.Printf("%-18s %8s %8s  %s\n", "dataset", "revision", "entries", "sha256")
+	for _, d := range manifest {
+		modified := ""
+		if d.Modified {
+			modified = "  (modified)"
+		}
+		fmt.Printf("%-18s %8d %8d  %s%s\n", d.Name, d.Revision, d.Entries, d.SHA256, modified)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/seckiss/webgeo"
+)
+
+var dbCommands = map[string]func(args []string) error{
+	"update":  dbUpdate,
+	"verify":  dbVerify,
+	"inspect": dbInspect,
+}
+
+func database(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: webgeo db update|verify|inspect [flags]")
+	}
+	cmd, pres := dbCommands[args[0]]
+	if !pres {
+		return fmt.Errorf("unknown db command %q, want update, verify or inspect", args[0])
+	}
+	return cmd(args[1:])
+}
+
+func dbUpdate(args []string) error {
+	fs := flag.NewFlagSet("db update", flag.ExitOnError)
+	path := fs.String("db", webgeo.DBPath, "where to install the database")
+	edition := fs.String("edition", "GeoLite2-City", "MaxMind edition, e.g. GeoLite2-ASN for --asn-db")
+	keyFile := fs.String("license-key-file", "", "file with the MaxMind license key, instead of $MAXMIND_LICENSE_KEY")
+	fs.Parse(args)
+	key := os.Getenv("MAXMIND_LICENSE_KEY")
+	if *keyFile != "" {
+		b, err := os.ReadFile(*keyFile)
+		if err != nil {
+			return err
+		}
+		key = strings.TrimSpace(string(b))
+	}
+	if key == "" {
+		return fmt.Errorf("set MAXMIND_LICENSE_KEY or --license-key-file")
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	updated, err := webgeo.UpdateDB(ctx, key, *edition, *path)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		fmt.Printf("%s is up to date\n", *path)
+		return nil
+	}
+	fmt.Printf("installed %s at %s\n", *edition, *path)
+	return nil
+}
+
+// Well known addresses and the country they are expected in
+var verifySamples = []struct {
+	ip string
+	cc string
+}{
+	{"8.8.8.8", "US"},
+	{"1.1.1.1", ""}, // anycast, any answer will do
+	{"2001:4860:4860::8888", "US"},
+	{"81.2.69.142", "GB"},
+}
+
+func dbVerify(args []string) error {
+	fs := flag.NewFlagSet("db verify", flag.ExitOnError)
+	path := fs.String("db", webgeo.DBPath, "database path")
+	asnPath := fs.String("asn-db", webgeo.ASNDBPath, "optional ASN database path")
+	fs.Parse(args)
+	// unlike lookups, never download a missing database
+	for _, p := range []string{*path, *asnPath} {
+		if _, err := os.Stat(p); p != "" && err != nil {
+			return err
+		}
+	}
+	g := webgeo.New(webgeo.WithDBPath(*path), webgeo.WithASNDBPath(*asnPath))
+	h := g.CheckHealth()
+	printDBInfo(h.Database)
+	if h.ASNDatabase != nil {
+		fmt.Println()
+		printDBInfo(*h.ASNDatabase)
+	}
+	if !h.Ready {
+		return fmt.Errorf("could not open %s: %s", *path, h.Database.Error)
+	}
+	if h.ASNDatabase != nil && h.ASNDatabase.Error != "" {
+		return fmt.Errorf("could not open %s: %s", *asnPath, h.ASNDatabase.Error)
+	}
+	fmt.Printf("\n%-7s %s\n", "age", h.Age)
+	if h.Stale {
+		fmt.Printf("warning: the database is older than %v, are updates running?\n", webgeo.StaleDBAge)
+	}
+
+	fmt.Println()
+	failed := 0
+	for _, s := range verifySamples {
+		addr := netip.MustParseAddr(s.ip)
+		results, err := g.LookupBatchE([]netip.Addr{addr})
+		if err != nil {
+			return err
+		}
+		res := results[0]
+		status := "ok"
+		if res.Country == "ZZ" || (s.cc != "" && res.Country != s.cc) {
+			status = "unexpected, want " + s.cc
+			failed++
+		}
+		fmt.Printf("%-22s %-3s %s\n", s.ip, res.Country, status)
+	}
+	if failed == len(verifySamples) {
+		return fmt.Errorf("no sample lookup gave the expected country")
+	}
+	return nil
+}
+
+func printDBInfo(info webgeo.DBInfo) {
+	fmt.Printf("%-7s %s\n", "path", info.Path)
+	if info.Error != "" {
+		fmt.Printf("%-7s %s\n", "error", info.Error)
+		return
+	}
+	fmt.Printf("%-7s %s\n", "type", info.Type)
+	fmt.Printf("%-7s %s\n", "built", info.BuildTime.Format("2006-01-02 15:04 MST"))
+	fmt.Printf("%-7s IPv%d, %d nodes\n", "tree", info.IPVersion, info.NodeCount)
+	if info.Description != "" {
+		fmt.Printf("%-7s %s\n", "about", info.Description)
+	}
+}
+
+// The metadata of the database, the network of ip and what webgeo makes of
+// it, with --raw the record as stored, for comparing with MaxMind's own
+// lookup page
+func dbInspect(args []string) error {
+	fs := flag.NewFlagSet("db inspect", flag.ExitOnError)
+	path := fs.String("db", webgeo.DBPath, "database path, City or ASN")
+	ipS := fs.String("ip", "", "IP to look up, only the metadata without it")
+	raw := fs.Bool("raw", false, "include the record as stored in the database")
+	fs.Parse(args)
+	r, err := maxminddb.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	m := r.Metadata
+	out := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"database_type":               m.DatabaseType,
+			"description":                 m.Description,
+			"languages":                   m.Languages,
+			"binary_format_major_version": m.BinaryFormatMajorVersion,
+			"binary_format_minor_version": m.BinaryFormatMinorVersion,
+			"build_epoch":                 m.BuildEpoch,
+			"build_time":                  time.Unix(int64(m.BuildEpoch), 0).UTC(),
+			"ip_version":                  m.IPVersion,
+			"node_count":                  m.NodeCount,
+			"record_size":                 m.RecordSize,
+		},
+	}
+	if *ipS != "" {
+		addr, err := netip.ParseAddr(*ipS)
+		if err != nil {
+			return fmt.Errorf("invalid IP %q", *ipS)
+		}
+		var record interface{}
+		network, found, err := r.LookupNetwork(net.IP(addr.AsSlice()), &record)
+		if err != nil {
+			return err
+		}
+		out["network"] = network.String()
+		out["found"] = found
+		if *raw {
+			out["raw"] = record
+		}
+		if strings.Contains(m.DatabaseType, "City") {
+			g := webgeo.New(webgeo.WithDBPath(*path), webgeo.WithoutCache())
+			results, err := g.LookupBatchE([]netip.Addr{addr})
+			if err != nil {
+				return err
+			}
+			out["webgeo"] = results[0]
+		}
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"strings"
+
+	"github.com/seckiss/webgeo"
+)
+
+// rows looked up together, bounding the memory whatever the size of the file
+const enrichChunk = 1000
+
+var enrichColumns = []string{"country", "city", "langs"}
+
+func enrich(args []string) error {
+	fs := flag.NewFlagSet("enrich", flag.ExitOnError)
+	in := fs.String("in", "-", "input CSV file, - for stdin")
+	out := fs.String("out", "-", "output CSV file, - for stdout")
+	col := fs.Int("col", 1, "column of the IPs, the first is 1")
+	header := fs.Bool("header", false, "the first row is a header, extended with the new column names")
+	comma := fs.String("sep", ",", "field separator")
+	db := fs.String("db", webgeo.DBPath, "database path")
+	fs.Parse(args)
+	if *col < 1 {
+		return fmt.Errorf("--col starts at 1")
+	}
+	sep := []rune(*comma)
+	if len(sep) != 1 {
+		return fmt.Errorf("--sep must be a single character")
+	}
+	webgeo.DBPath = *db
+
+	r := io.Reader(os.Stdin)
+	if *in != "-" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	w := io.Writer(os.Stdout)
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	cr := csv.NewReader(r)
+	cr.Comma, cr.FieldsPerRecord, cr.LazyQuotes = sep[0], -1, true
+	cw := csv.NewWriter(w)
+	cw.Comma = sep[0]
+
+	// short rows are padded to the first one, so the new columns line up
+	width := 0
+	if *header {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := cw.Write(append(row, enrichColumns...)); err != nil {
+			return err
+		}
+		width = len(row)
+	}
+	for {
+		rows, err := readRows(cr, enrichChunk)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			break
+		}
+		if width == 0 {
+			width = len(rows[0])
+		}
+		if err := writeEnriched(cw, rows, *col-1, width); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func readRows(cr *csv.Reader, n int) ([][]string, error) {
+	rows := make([][]string, 0, n)
+	for len(rows) < n {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func writeEnriched(cw *csv.Writer, rows [][]string, col, width int) error {
+	addrs := make([]netip.Addr, len(rows))
+	for i, row := range rows {
+		if col < len(row) {
+			// invalid IPs stay zero and get ZZ
+			addrs[i] = parseAddr(row[col])
+		}
+	}
+	results, err := webgeo.LookupBatchE(addrs)
+	if err != nil {
+		return err
+	}
+	for i, res := range results {
+		city := ""
+		if res.Geo != nil {
+			city = res.Geo.City
+		}
+		row := rows[i]
+		for len(row) < width {
+			row = append(row, "")
+		}
+		if err := cw.Write(append(row, res.Country, city, strings.Join(res.Langs, " "))); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// An IP, possibly with a port, the zero Addr when s is neither
+func parseAddr(s string) netip.Addr {
+	s = strings.TrimSpace(s)
+	if ap, err := netip.ParseAddrPort(s); err == nil {
+		return ap.Addr()
+	}
+	addr, _ := netip.ParseAddr(s)
+	return addr
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seckiss/webgeo"
+)
+
+const commonLog = `^(\S+) \S+ (\S+) \[([^\]]+)\] "((?:[^"\\]|\\.)*)" (\d{3}|-) (\d+|-)`
+
+// the combined format adds the referer and the user agent
+var accessLogRes = map[string]*regexp.Regexp{
+	"common":   regexp.MustCompile(commonLog),
+	"combined": regexp.MustCompile(commonLog + ` "((?:[^"\\]|\\.)*)" "((?:[^"\\]|\\.)*)"`),
+}
+
+const accessLogTime = "02/Jan/2006:15:04:05 -0700"
+
+// max length of an access log line
+const maxLogLine = 1 << 20
+
+type accessLogEntry struct {
+	IP        string `json:"ip"`
+	User      string `json:"user,omitempty"`
+	Time      string `json:"time"`
+	Method    string `json:"method,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Protocol  string `json:"protocol,omitempty"`
+	Status    int    `json:"status,omitempty"`
+	Bytes     int64  `json:"bytes"`
+	Referer   string `json:"referer,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+func logs(args []string) error {
+	fs := flag.NewFlagSet("logs", flag.ExitOnError)
+	format := fs.String("format", "combined", "log format: combined or common (nginx and Apache defaults)")
+	summary := fs.Bool("summary", false, "print a table of requests per country instead of JSON lines")
+	top := fs.Int("top", 20, "countries in the summary, 0 for all")
+	db := fs.String("db", webgeo.DBPath, "database path")
+	files := parseInterspersed(fs, args)
+	re, pres := accessLogRes[*format]
+	if !pres {
+		return fmt.Errorf("unknown --format %q", *format)
+	}
+	webgeo.DBPath = *db
+
+	r := io.Reader(os.Stdin)
+	if len(files) > 0 {
+		readers := []io.Reader{}
+		for _, path := range files {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			readers = append(readers, f)
+		}
+		r = io.MultiReader(readers...)
+	}
+	lr := &logReader{sc: bufio.NewScanner(r), re: re}
+	lr.sc.Buffer(make([]byte, 64*1024), maxLogLine)
+
+	var err error
+	if *summary {
+		err = summarizeLogs(lr, *top)
+	} else {
+		err = enrichLogs(lr)
+	}
+	if lr.skipped > 0 {
+		fmt.Fprintf(os.Stderr, "webgeo logs: skipped %d of %d lines not in %s format\n", lr.skipped, lr.lines, *format)
+	}
+	return err
+}
+
+type logReader struct {
+	sc      *bufio.Scanner
+	re      *regexp.Regexp
+	lines   int
+	skipped int
+}
+
+// The next parsed entry, false at the end of the input
+func (lr *logReader) next() (accessLogEntry, bool, error) {
+	for lr.sc.Scan() {
+		line := lr.sc.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lr.lines++
+		e, ok := parseAccessLog(line, lr.re)
+		if !ok {
+			lr.skipped++
+			continue
+		}
+		return e, true, nil
+	}
+	return accessLogEntry{}, false, lr.sc.Err()
+}
+
+func parseAccessLog(line string, re *regexp.Regexp) (accessLogEntry, bool) {
+	m := re.FindStringSubmatch(line)
+	if m == nil {
+		return accessLogEntry{}, false
+	}
+	e := accessLogEntry{IP: m[1], Time: m[3]}
+	if len(m) > 8 {
+		e.Referer, e.UserAgent = unescapeLog(m[7]), unescapeLog(m[8])
+	}
+	if m[2] != "-" {
+		e.User = m[2]
+	}
+	if t, err := time.Parse(accessLogTime, m[3]); err == nil {
+		e.Time = t.Format(time.RFC3339)
+	}
+	if parts := strings.Fields(unescapeLog(m[4])); len(parts) == 3 {
+		e.Method, e.Path, e.Protocol = parts[0], parts[1], parts[2]
+	} else {
+		// garbage sent instead of a request line, e.g. a TLS handshake
+		e.Path = unescapeLog(m[4])
+	}
+	e.Status, _ = strconv.Atoi(m[5])
+	e.Bytes, _ = strconv.ParseInt(m[6], 10, 64)
+	if e.Referer == "-" {
+		e.Referer = ""
+	}
+	if e.UserAgent == "-" {
+		e.UserAgent = ""
+	}
+	return e, true
+}
+
+// Undo the \" and \\ escaping of nginx and Apache
+func unescapeLog(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(s)
+}
+
+// JSON lines with the geo record, see webgeo.EnrichStream
+func enrichLogs(lr *logReader) error {
+	pr, pw := io.Pipe()
+	go func() {
+		enc := json.NewEncoder(pw)
+		for {
+			e, ok, err := lr.next()
+			if err != nil || !ok {
+				pw.CloseWithError(err)
+				return
+			}
+			if err := enc.Encode(e); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	err := webgeo.EnrichStream(pr, os.Stdout)
+	// unblock the writer when EnrichStream failed
+	pr.CloseWithError(err)
+	return err
+}
+
+type countrySummary struct {
+	cc, name string
+	requests int
+	bytes    int64
+}
+
+func summarizeLogs(lr *logReader, top int) error {
+	byCountry := make(map[string]*countrySummary)
+	total := 0
+	addrs := make([]netip.Addr, 0, enrichChunk)
+	sizes := make([]int64, 0, enrichChunk)
+	flush := func() error {
+		results, err := webgeo.LookupBatchE(addrs)
+		if err != nil {
+			return err
+		}
+		for i, res := range results {
+			s, pres := byCountry[res.Country]
+			if !pres {
+				s = &countrySummary{cc: res.Country, name: "unknown"}
+				byCountry[res.Country] = s
+			}
+			if res.Geo != nil && res.Geo.Country != "" {
+				s.name = res.Geo.Country
+			}
+			s.requests++
+			s.bytes += sizes[i]
+		}
+		total += len(addrs)
+		addrs, sizes = addrs[:0], sizes[:0]
+		return nil
+	}
+	for {
+		e, ok, err := lr.next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		addrs = append(addrs, parseAddr(e.IP))
+		sizes = append(sizes, e.Bytes)
+		if len(addrs) == enrichChunk {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	list := make([]*countrySummary, 0, len(byCountry))
+	for _, s := range byCountry {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].requests != list[j].requests {
+			return list[i].requests > list[j].requests
+		}
+		return list[i].cc < list[j].cc
+	})
+	if top > 0 && len(list) > top {
+		list = list[:top]
+	}
+	fmt.Printf("%-3s %-24s %10s %7s %12s\n", "cc", "country", "requests", "share", "MiB")
+	for _, s := range list {
+		fmt.Printf("%-3s %-24s %10d %6.1f%% %12.1f\n", s.cc, s.name, s.requests,
+			100*float64(s.requests)/float64(total), mib(s.bytes))
+	}
+	fmt.Printf("\n%d requests from %d countries\n", total, len(byCountry))
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/netip"
+	"os"
+	"strings"
+
+	"github.com/seckiss/webgeo"
+)
+
+func lookup(args []string) error {
+	fs := flag.NewFlagSet("lookup", flag.ExitOnError)
+	db := fs.String("db", webgeo.DBPath, "database path")
+	asnDB := fs.String("asn-db", webgeo.ASNDBPath, "optional ASN database path")
+	asJSON := fs.Bool("json", false, "print the results as JSON, one per line")
+	ips := parseInterspersed(fs, args)
+	if len(ips) == 0 {
+		return fmt.Errorf("usage: webgeo lookup [--json] IP...")
+	}
+	webgeo.DBPath, webgeo.ASNDBPath = *db, *asnDB
+
+	enc := json.NewEncoder(os.Stdout)
+	for i, ip := range ips {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return fmt.Errorf("invalid IP %q", ip)
+		}
+		results, err := webgeo.LookupBatchE([]netip.Addr{addr})
+		if err != nil {
+			return err
+		}
+		res := results[0]
+		if *asJSON {
+			if err := enc.Encode(res); err != nil {
+				return err
+			}
+			continue
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		printResult(addr, res)
+	}
+	return nil
+}
+
+func printResult(addr netip.Addr, res webgeo.Result) {
+	field := func(name, format string, v ...interface{}) {
+		fmt.Printf("%-14s "+format+"\n", append([]interface{}{name}, v...)...)
+	}
+	field("ip", "%s", addr)
+	geo := res.Geo
+	if geo == nil {
+		field("country", "ZZ (not in the database)")
+		return
+	}
+	field("country", "%s %s", res.Country, geo.Country)
+	if geo.City != "" {
+		field("city", "%s", geo.City)
+	}
+	for _, s := range geo.Subdivisions {
+		field("subdivision", "%s %s", s.IsoCode, s.Name)
+	}
+	field("continent", "%s %s", geo.ContinentCode, geo.Continent)
+	if geo.Latitude != 0 || geo.Longitude != 0 {
+		field("coordinates", "%.4f, %.4f (±%d km)", geo.Latitude, geo.Longitude, geo.AccuracyRadius)
+	}
+	if geo.TimeZone != "" {
+		field("time zone", "%s", geo.TimeZone)
+	}
+	field("langs", "%s", strings.Join(res.Langs, ", "))
+	if res.Currency != "" {
+		field("currency", "%s %s", res.Currency, res.CurrencyName)
+	}
+	if res.CallingCode != "" {
+		field("calling code", "%s", res.CallingCode)
+	}
+	if geo.AutonomousSystemNumber != 0 {
+		field("asn", "AS%d %s", geo.AutonomousSystemNumber, geo.AutonomousSystemOrganization)
+	}
+	if geo.RegisteredCountry != "" && geo.RegisteredCountry != res.Country {
+		field("registered in", "%s", geo.RegisteredCountry)
+	}
+	if geo.RepresentedCountry != "" {
+		field("represents", "%s", geo.RepresentedCountry)
+	}
+	if geo.IsInEuropeanUnion {
+		field("eu", "yes")
+	}
+	if geo.IsAnonymousProxy {
+		field("anonymous", "yes")
+	}
+	if geo.IsSatelliteProvider {
+		field("satellite", "yes")
+	}
+	for _, w := range geo.Warnings {
+		field("warning", "%s", w)
+	}
+}
+
+// Parse the flags of fs wherever they are in args, so "lookup 1.2.3.4
+// --json" works, and return the other arguments
+func parseInterspersed(fs *flag.FlagSet, args []string) []string {
+	fs.Parse(args)
+	rest := []string{}
+	for fs.NArg() > 0 {
+		rest = append(rest, fs.Arg(0))
+		fs.Parse(fs.Args()[1:])
+	}
+	return rest
+}
@@ -0,0 +1,61 @@
+// Command webgeo exposes the webgeo package to operators.
+//
+//	webgeo bench --ips sample.txt [--db GeoLite2-City.mmdb] [--mode mmap|memory] [--warm networks.txt]
+//	webgeo countries export [--format json|csv|yaml] [--out countries.json]
+//	webgeo data [--json]
+//	webgeo db update [--db GeoLite2-City.mmdb] [--edition GeoLite2-City] [--license-key-file key.txt]
+//	webgeo db verify [--db GeoLite2-City.mmdb] [--asn-db GeoLite2-ASN.mmdb]
+//	webgeo db inspect [--db GeoLite2-City.mmdb] [--ip 1.2.3.4 [--raw]]
+//	webgeo enrich [--in ips.csv] [--out enriched.csv] [--col 1] [--header] [--sep ,] [--db GeoLite2-City.mmdb]
+//	webgeo logs [--format combined|common] [--summary [--top 20]] [--db GeoLite2-City.mmdb] [access.log...]
+//	webgeo lookup [--json] [--db GeoLite2-City.mmdb] [--asn-db GeoLite2-ASN.mmdb] IP...
+//	webgeo serve [--listen :8080] [--db GeoLite2-City.mmdb] [--asn-db GeoLite2-ASN.mmdb] [--trusted-proxies 10.0.0.0/8] [--cors origins] [--max-ips 1000]
+//	webgeo tail [-f [--from-start]] [--format combined|common] [--interval 2s] [--top 10] [--db GeoLite2-City.mmdb] access.log
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+var commands = map[string]func(args []string) error{
+	"bench":     bench,
+	"countries": countries,
+	"data":      data,
+	"db":        database,
+	"enrich":    enrich,
+	"logs":      logs,
+	"lookup":    lookup,
+	"serve":     serve,
+	"tail":      tail,
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: webgeo <command> [flags]\n\ncommands:\n")
+	fmt.Fprintf(os.Stderr, "  bench      measure lookup latency, cache hit rate and memory on a sample of IPs\n")
+	fmt.Fprintf(os.Stderr, "  countries  export the country table: languages, currencies, TLDs\n")
+	fmt.Fprintf(os.Stderr, "  data       print the revisions and hashes of the bundled datasets\n")
+	fmt.Fprintf(os.Stderr, "  db         update, verify or inspect the database\n")
+	fmt.Fprintf(os.Stderr, "  enrich     append country, city and languages to the rows of a CSV file\n")
+	fmt.Fprintf(os.Stderr, "  logs       geolocate the clients of nginx or Apache access logs\n")
+	fmt.Fprintf(os.Stderr, "  lookup     print what webgeo knows about IPs\n")
+	fmt.Fprintf(os.Stderr, "  serve      run an HTTP geolocation service with health and metrics endpoints\n")
+	fmt.Fprintf(os.Stderr, "  tail       show the top countries and cities of an access log as it grows\n")
+	fmt.Fprintf(os.Stderr, "\nRun 'webgeo <command> -h' for the flags of a command.\n")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, pres := commands[os.Args[1]]
+	if !pres {
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "webgeo %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/netip"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/seckiss/webgeo"
+)
+
+func serve(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	listen := fs.String("listen", ":8080", "address to listen on")
+	db := fs.String("db", webgeo.DBPath, "database path")
+	asnDB := fs.String("asn-db", webgeo.ASNDBPath, "optional ASN database path")
+	proxies := fs.String("trusted-proxies", "", "comma separated networks of the proxies in front, e.g. 10.0.0.0/8")
+	cors := fs.String("cors", "", "comma separated origins allowed to call /geoip from browsers, * for all")
+	maxIPs := fs.Int("max-ips", 1000, "most ip parameters of a /lookup request")
+	fs.Parse(args)
+
+	opts := []webgeo.Option{webgeo.WithDBPath(*db), webgeo.WithASNDBPath(*asnDB)}
+	if *proxies != "" {
+		prefixes, err := webgeo.ParsePrefixes(strings.Split(*proxies, ",")...)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, webgeo.WithTrustedProxies(prefixes...))
+	}
+	if *cors != "" {
+		opts = append(opts, webgeo.WithCORS(strings.Split(*cors, ",")...))
+	}
+	g := webgeo.New(opts...)
+	m := &serveMetrics{requests: make(map[[2]string]uint64)}
+
+	mux := http.NewServeMux()
+	mux.Handle("/geoip", m.count("geoip", g.Handler()))
+	mux.Handle("/lookup", m.count("lookup", lookupHandler(g, *maxIPs)))
+	mux.Handle("/healthz", m.count("healthz", g.HealthHandler()))
+	mux.Handle("/metrics", m.handler(g))
+	srv := &http.Server{Addr: *listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	// drain the connections on SIGINT and SIGTERM
+	done := make(chan error, 1)
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		done <- srv.Shutdown(ctx)
+	}()
+	log.Printf("webgeo: serving /geoip, /lookup, /healthz and /metrics on %s", *listen)
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return <-done
+}
+
+// The Results of the ip query parameters, e.g. /lookup?ip=192.0.2.1&ip=...,
+// in their order; a single ip gives a single object. Requests with more than
+// maxIPs are refused.
+func lookupHandler(g *webgeo.Geolocator, maxIPs int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ips := r.URL.Query()["ip"]
+		if len(ips) == 0 {
+			http.Error(w, "Missing ip parameter", http.StatusBadRequest)
+			return
+		}
+		if len(ips) > maxIPs {
+			http.Error(w, fmt.Sprintf("Too many IPs: %d, at most %d", len(ips), maxIPs), http.StatusBadRequest)
+			return
+		}
+		addrs := make([]netip.Addr, len(ips))
+		for i, ip := range ips {
+			if addrs[i] = parseAddr(ip); !addrs[i].IsValid() {
+				http.Error(w, fmt.Sprintf("Invalid IP %q", ip), http.StatusBadRequest)
+				return
+			}
+		}
+		results, err := g.LookupBatchE(addrs)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		var v interface{} = results
+		if len(ips) == 1 {
+			v = results[0]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			log.Printf("webgeo: could not write lookup: %v", err)
+		}
+	})
+}
+
+// Request counters by handler and status code, served with the cache
+// counters in the Prometheus text format
+type serveMetrics struct {
+	mutex    sync.Mutex
+	requests map[[2]string]uint64
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (m *serveMetrics) count(name string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{w, http.StatusOK}
+		next.ServeHTTP(rec, r)
+		m.mutex.Lock()
+		m.requests[[2]string{name, fmt.Sprint(rec.status)}]++
+		m.mutex.Unlock()
+	})
+}
+
+func (m *serveMetrics) handler(g *webgeo.Geolocator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		metric := func(name, typ, help string, v interface{}) {
+			fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, typ, name, v)
+		}
+		c := g.CacheStats()
+		metric("webgeo_cache_hits_total", "counter", "Lookups served from the cache.", c.Hits)
+		metric("webgeo_cache_misses_total", "counter", "Lookups that went to the database.", c.Misses)
+		metric("webgeo_cache_evictions_total", "counter", "Cache entries dropped to make room.", c.Evictions)
+		metric("webgeo_cache_entries", "gauge", "Entries in the cache.", c.Size)
+		metric("webgeo_cache_bytes", "gauge", "Approximate memory of the cache entries.", c.Bytes)
+		h := g.CheckHealth()
+		ready := 0
+		if h.Ready {
+			ready = 1
+			metric("webgeo_database_build_timestamp_seconds", "gauge", "Build time of the database.", h.Database.BuildTime.Unix())
+		}
+		metric("webgeo_ready", "gauge", "Whether the database is open.", ready)
+
+		m.mutex.Lock()
+		keys := make([][2]string, 0, len(m.requests))
+		for k := range m.requests {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i][0] < keys[j][0] || (keys[i][0] == keys[j][0] && keys[i][1] < keys[j][1])
+		})
+		fmt.Fprintf(w, "# HELP webgeo_http_requests_total Requests by handler and status code.\n# TYPE webgeo_http_requests_total counter\n")
+		for _, k := range keys {
+			fmt.Fprintf(w, "webgeo_http_requests_total{handler=%q,code=%q} %d\n", k[0], k[1], m.requests[k])
+		}
+		m.mutex.Unlock()
+	})
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/seckiss/webgeo"
+)
+
+// how often a followed log is checked for new lines and rotation
+const tailPoll = 250 * time.Millisecond
+
+func tail(args []string) error {
+	fs := flag.NewFlagSet("tail", flag.ExitOnError)
+	follow := fs.Bool("f", false, "follow the log as it grows, across rotations")
+	fromStart := fs.Bool("from-start", false, "with -f, count the lines already in the log too")
+	format := fs.String("format", "combined", "log format: combined or common (nginx and Apache defaults)")
+	interval := fs.Duration("interval", 2*time.Second, "refresh interval of the table")
+	top := fs.Int("top", 10, "rows per table")
+	db := fs.String("db", webgeo.DBPath, "database path")
+	files := parseInterspersed(fs, args)
+	re, pres := accessLogRes[*format]
+	if !pres {
+		return fmt.Errorf("unknown --format %q", *format)
+	}
+	if len(files) != 1 {
+		return fmt.Errorf("usage: webgeo tail [-f] access.log")
+	}
+	webgeo.DBPath = *db
+
+	t := &logTail{path: files[0], stats: newTailStats()}
+	if err := t.open(*follow && !*fromStart); err != nil {
+		return err
+	}
+	defer t.f.Close()
+	if !*follow {
+		if _, err := t.read(re); err != nil {
+			return err
+		}
+		t.stats.render(*top, 0)
+		return nil
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	poll := time.NewTicker(tailPoll)
+	defer poll.Stop()
+	refresh := time.NewTicker(*interval)
+	defer refresh.Stop()
+	for {
+		select {
+		case <-sig:
+			return nil
+		case <-poll.C:
+			n, err := t.read(re)
+			if err != nil {
+				return err
+			}
+			if n == 0 {
+				if err := t.checkRotation(); err != nil {
+					return err
+				}
+			}
+		case <-refresh.C:
+			fmt.Print("\033[H\033[2J")
+			t.stats.render(*top, *interval)
+			t.stats.startInterval()
+		}
+	}
+}
+
+type logTail struct {
+	path    string
+	f       *os.File
+	r       *bufio.Reader
+	partial string // of a line still being written
+	offset  int64
+	stats   *tailStats
+}
+
+func (t *logTail) open(atEnd bool) error {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return err
+	}
+	if t.f != nil {
+		t.f.Close()
+	}
+	t.f, t.r, t.partial, t.offset = f, bufio.NewReader(f), "", 0
+	if atEnd {
+		if t.offset, err = f.Seek(0, io.SeekEnd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start over when the log was rotated (another file at path) or truncated
+func (t *logTail) checkRotation() error {
+	cur, err := t.f.Stat()
+	if err != nil {
+		return err
+	}
+	fi, err := os.Stat(t.path)
+	if err != nil {
+		// moved away, the new one isn't there yet
+		return nil
+	}
+	if !os.SameFile(cur, fi) || fi.Size() < t.offset {
+		return t.open(false)
+	}
+	return nil
+}
+
+// Count the complete lines available, returns how many bytes were read
+func (t *logTail) read(re *regexp.Regexp) (int, error) {
+	read := 0
+	addrs := make([]netip.Addr, 0, enrichChunk)
+	flush := func() error {
+		results, err := webgeo.LookupBatchE(addrs)
+		if err != nil {
+			return err
+		}
+		t.stats.add(results)
+		addrs = addrs[:0]
+		return nil
+	}
+	for {
+		line, err := t.r.ReadString('\n')
+		read += len(line)
+		t.offset += int64(len(line))
+		if err == io.EOF {
+			t.partial += line
+			break
+		}
+		if err != nil {
+			return read, err
+		}
+		line, t.partial = t.partial+line, ""
+		if e, ok := parseAccessLog(line, re); ok {
+			addrs = append(addrs, parseAddr(e.IP))
+		} else {
+			t.stats.skipped++
+		}
+		if len(addrs) == enrichChunk {
+			if err := flush(); err != nil {
+				return read, err
+			}
+		}
+	}
+	return read, flush()
+}
+
+type tailCount struct {
+	name     string
+	total    int
+	interval int // since the last refresh
+}
+
+type tailStats struct {
+	countries map[string]*tailCount
+	cities    map[string]*tailCount
+	total     int
+	interval  int
+	skipped   int
+	since     time.Time
+}
+
+func newTailStats() *tailStats {
+	return &tailStats{countries: map[string]*tailCount{}, cities: map[string]*tailCount{}, since: time.Now()}
+}
+
+func (s *tailStats) add(results []webgeo.Result) {
+	for _, res := range results {
+		s.total++
+		s.interval++
+		name := "unknown"
+		if res.Geo != nil && res.Geo.Country != "" {
+			name = res.Geo.Country
+		}
+		countTail(s.countries, res.Country, name)
+		if res.Geo != nil && res.Geo.City != "" {
+			city := res.Geo.City + ", " + res.Country
+			countTail(s.cities, city, city)
+		}
+	}
+}
+
+func countTail(m map[string]*tailCount, key, name string) {
+	c, pres := m[key]
+	if !pres {
+		c = &tailCount{name: name}
+		m[key] = c
+	}
+	c.total++
+	c.interval++
+}
+
+func (s *tailStats) startInterval() {
+	s.interval = 0
+	for _, c := range s.countries {
+		c.interval = 0
+	}
+	for _, c := range s.cities {
+		c.interval = 0
+	}
+}
+
+// Print the top countries and cities, with their rates over interval
+// unless it is 0
+func (s *tailStats) render(top int, interval time.Duration) {
+	rate := func(n int) string {
+		if interval == 0 {
+			return ""
+		}
+		return fmt.Sprintf(" %8.1f/s", float64(n)/interval.Seconds())
+	}
+	fmt.Printf("%d requests since %s%s", s.total, s.since.Format("15:04:05"), rate(s.interval))
+	if s.skipped > 0 {
+		fmt.Printf(", %d unparsable lines", s.skipped)
+	}
+	fmt.Print("\n\n")
+	table := func(title string, m map[string]*tailCount) {
+		list := make([]*tailCount, 0, len(m))
+		for _, c := range m {
+			list = append(list, c)
+		}
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].total != list[j].total {
+				return list[i].total > list[j].total
+			}
+			return list[i].name < list[j].name
+		})
+		if len(list) > top {
+			list = list[:top]
+		}
+		fmt.Printf("%-32s %10s %7s\n", title, "requests", "share")
+		for _, c := range list {
+			fmt.Printf("%-32s %10d %6.1f%%%s\n", c.name, c.total, 100*float64(c.total)/float64(s.total), rate(c.interval))
+		}
+		fmt.Println()
+	}
+	table("country", s.countries)
+	table("city", s.cities)
+}
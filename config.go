@@ -0,0 +1,122 @@
+package webgeo
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+
+	"golang.org/x/text/language"
+)
+
+// ConfigSnapshot is a read-only view of the settings in effect, for debug
+// pages and bug reports. Secrets are never included, only whether they are set.
+type ConfigSnapshot struct {
+	DBPath         string   `json:"db_path"`
+	ASNDBPath      string   `json:"asn_db_path,omitempty"`
+	DBInMemory     bool     `json:"db_in_memory"`
+	CacheSize      int      `json:"cache_size"`  // 0 is unbounded
+	CacheBytes     int      `json:"cache_bytes"` // 0 is unbounded
+	CacheTTL       string   `json:"cache_ttl"`   // 0s never expires
+	NegativeTTL    string   `json:"negative_cache_ttl"`
+	CacheByPrefix  bool     `json:"cache_by_prefix"`
+	Cache          string   `json:"cache"` // type of the Cache
+	TrustedProxies []string `json:"trusted_proxies"`
+	ClientIPFunc   string   `json:"client_ip_func"`
+	SkipMethods    []string `json:"skip_methods"`
+
+	LangConflictPolicy string   `json:"lang_conflict_policy"`
+	LangPrecedence     string   `json:"lang_precedence"`
+	MaxLangs           int      `json:"max_langs"` // 0 is unlimited
+	FallbackLang       string   `json:"fallback_lang,omitempty"`
+	FallbackChains     bool     `json:"fallback_chains"`
+	ContentLanguage    bool     `json:"content_language"`
+	ServerTiming       bool     `json:"server_timing"`
+	JSONPParam         string   `json:"jsonp_param,omitempty"`
+	CORSOrigins        []string `json:"cors_origins"`
+	LangCookie         string   `json:"lang_cookie,omitempty"`
+	LangParam          string   `json:"lang_param,omitempty"`
+	LocaleCookie       string   `json:"locale_cookie,omitempty"`
+	LangLearning       bool     `json:"lang_learning"`
+	StrictCodes        bool     `json:"strict_codes"`
+	DryRun             bool     `json:"dry_run"`
+	ReputationProvider string   `json:"reputation_provider"`
+	FallbackProvider   string   `json:"fallback_provider,omitempty"` // type, never its token
+	// languages with translated problem details
+	ProblemLocales []string `json:"problem_locales"`
+
+	Markets         []Market            `json:"markets"`
+	AssetHosts      map[string]string   `json:"asset_hosts,omitempty"`
+	BlockedServices map[string][]string `json:"blocked_services,omitempty"`
+}
+
+// EffectiveConfig returns the settings used by the package level functions
+func EffectiveConfig() ConfigSnapshot {
+	return defaultGeolocator.EffectiveConfig()
+}
+
+// EffectiveConfig returns the settings of g, including the package variables
+// it falls back to
+func (g *Geolocator) EffectiveConfig() ConfigSnapshot {
+	c := ConfigSnapshot{
+		DBPath:         g.cityPath(),
+		ASNDBPath:      g.asnPath(),
+		DBInMemory:     g.dbInMemory(),
+		CacheSize:      g.maxCacheEntries(),
+		CacheBytes:     g.maxCacheBytes(),
+		CacheTTL:       g.cacheLifetime().String(),
+		NegativeTTL:    g.negativeLifetime().String(),
+		CacheByPrefix:  g.cacheByPrefix(),
+		Cache:          fmt.Sprintf("%T", g.cache),
+		TrustedProxies: []string{},
+		SkipMethods:    []string{},
+
+		LangConflictPolicy: LangConflictPolicy.String(),
+		LangPrecedence:     g.precedence.String(),
+		MaxLangs:           g.maxLangs,
+		FallbackChains:     g.chains,
+		ContentLanguage:    g.contentLang,
+		ServerTiming:       g.serverTiming,
+		JSONPParam:         g.jsonpParam,
+		CORSOrigins:        append([]string{}, g.corsOrigins...),
+		LangCookie:         g.langCookie,
+		LangParam:          g.langParam,
+		LocaleCookie:       g.localeCookie,
+		LangLearning:       LangLearner != nil,
+		StrictCodes:        StrictCodes,
+		DryRun:             DryRun,
+		ReputationProvider: fmt.Sprintf("%T", ReputationProvider),
+		ProblemLocales:     []string{},
+
+		Markets:         Markets,
+		AssetHosts:      AssetHosts,
+		BlockedServices: BlockedServices,
+	}
+	if f := g.fallbackProvider(); f != nil {
+		c.FallbackProvider = fmt.Sprintf("%T", f)
+	}
+	if g.fallbackLang != language.Und {
+		c.FallbackLang = g.fallbackLang.String()
+	}
+	proxies, clientIP := g.proxies, g.clientIP
+	if proxies == nil {
+		proxies = TrustedProxies
+	}
+	if clientIP == nil {
+		clientIP = ClientIPFunc
+	}
+	for _, p := range proxies {
+		c.TrustedProxies = append(c.TrustedProxies, p.String())
+	}
+	c.ClientIPFunc = runtime.FuncForPC(reflect.ValueOf(clientIP).Pointer()).Name()
+	for m, skip := range SkipMethods {
+		if skip {
+			c.SkipMethods = append(c.SkipMethods, m)
+		}
+	}
+	sort.Strings(c.SkipMethods)
+	for _, t := range ProblemCatalog.Languages() {
+		c.ProblemLocales = append(c.ProblemLocales, t.String())
+	}
+	return c
+}
@@ -0,0 +1,91 @@
+package webgeo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// What to do when the languages of the visitor's country have nothing in
+// common with the browser languages, e.g. a JP IP with only pt-BR accepted
+type ConflictPolicy int
+
+const (
+	// Return both, browser languages and country languages
+	ConflictMerge ConflictPolicy = iota
+	// Drop the country languages, the visitor is likely travelling or on a VPN
+	ConflictPreferBrowser
+	// Drop the browser languages
+	ConflictPreferGeo
+)
+
+func (p ConflictPolicy) String() string {
+	switch p {
+	case ConflictMerge:
+		return "merge"
+	case ConflictPreferBrowser:
+		return "prefer-browser"
+	case ConflictPreferGeo:
+		return "prefer-geo"
+	}
+	return fmt.Sprintf("ConflictPolicy(%d)", int(p))
+}
+
+// LangConflictPolicy applies to CalcCountryAndLangs, Resolve and locale
+// negotiation. Result.Conflict is set whatever the policy, so the UI can
+// offer a language switch instead of silently guessing.
+var LangConflictPolicy = ConflictMerge
+
+// How browser and country languages are combined, see WithPrecedence
+type Precedence int
+
+const (
+	// Browser languages first, then the ones of the country
+	BrowserFirst Precedence = iota
+	// Country languages first, then the browser ones
+	GeoFirst
+	// Country languages only when the request has no Accept-Language
+	GeoAsFallback
+)
+
+func (p Precedence) String() string {
+	switch p {
+	case BrowserFirst:
+		return "browser-first"
+	case GeoFirst:
+		return "geo-first"
+	case GeoAsFallback:
+		return "geo-as-fallback"
+	}
+	return fmt.Sprintf("Precedence(%d)", int(p))
+}
+
+// Apply LangConflictPolicy to browser and geo languages
+func resolveConflict(blangs, glangs []string) ([]string, []string, bool) {
+	if !langsConflict(blangs, glangs) {
+		return blangs, glangs, false
+	}
+	switch LangConflictPolicy {
+	case ConflictPreferBrowser:
+		glangs = []string{}
+	case ConflictPreferGeo:
+		blangs = []string{}
+	}
+	return blangs, glangs, true
+}
+
+// No conflict unless both sides are known and share no base language
+func langsConflict(blangs, glangs []string) bool {
+	if len(blangs) == 0 || len(glangs) == 0 {
+		return false
+	}
+	bases := make(map[string]bool)
+	for _, b := range blangs {
+		bases[strings.ToLower(strings.Split(b, "-")[0])] = true
+	}
+	for _, g := range glangs {
+		if bases[strings.ToLower(strings.Split(g, "-")[0])] {
+			return false
+		}
+	}
+	return true
+}
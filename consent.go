@@ -0,0 +1,124 @@
+package webgeo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Privacy regimes used to pick consent defaults
+const (
+	RegimeEU    = "EU"
+	RegimeEEA   = "EEA" // EEA members outside the EU
+	RegimeUK    = "UK"
+	RegimeCH    = "CH"
+	RegimeOther = "other"
+)
+
+// ConsentDefaults are the initial states for analytics consent mode, e.g.
+// gtag('consent', 'default', {{.}}) in a template
+type ConsentDefaults struct {
+	AdStorage         string `json:"ad_storage"`
+	AnalyticsStorage  string `json:"analytics_storage"`
+	AdUserData        string `json:"ad_user_data"`
+	AdPersonalization string `json:"ad_personalization"`
+}
+
+var ConsentGranted = ConsentDefaults{"granted", "granted", "granted", "granted"}
+var ConsentDenied = ConsentDefaults{"denied", "denied", "denied", "denied"}
+
+// ConsentModeDefaults maps a privacy regime or a country code to its consent
+// defaults. A country entry wins over its regime, anything not listed gets
+// ConsentGranted. Add "ZZ" to decide for visitors of unknown location.
+var ConsentModeDefaults = map[string]ConsentDefaults{
+	RegimeEU:  ConsentDenied,
+	RegimeEEA: ConsentDenied,
+	RegimeUK:  ConsentDenied,
+	RegimeCH:  ConsentDenied,
+}
+
+// ConsentModeFor returns the recommended consent defaults for the visitor
+func ConsentModeFor(res Result) ConsentDefaults {
+	if c, pres := ConsentModeDefaults[res.Country]; pres {
+		return c
+	}
+	if c, pres := ConsentModeDefaults[privacyRegime(res)]; pres {
+		return c
+	}
+	return ConsentGranted
+}
+
+func privacyRegime(res Result) string {
+	return string(privacyRegion(res.Geo, res.Country))
+}
+
+// Region is a privacy regime, one of the Regime constants
+type Region string
+
+// PrivacyRegion classifies the location of record by privacy regime, so
+// consent banners are only shown where the law asks for them. Records the
+// database flags as EU are RegimeEU, the others are looked up in the bundled
+// country lists. A nil record is RegimeOther.
+func PrivacyRegion(record *GeoRecord) Region {
+	if record == nil {
+		return RegimeOther
+	}
+	return privacyRegion(record, record.Cc)
+}
+
+func privacyRegion(geo *GeoRecord, cc string) Region {
+	if geo != nil && geo.IsInEuropeanUnion {
+		return RegimeEU
+	}
+	if regime, pres := regimeCountries[cc]; pres {
+		return Region(regime)
+	}
+	return RegimeOther
+}
+
+// ConsentForUnknown makes ConsentRequired ask visitors of unknown location
+var ConsentForUnknown = true
+
+// ConsentRequired reports whether the visitor is in a privacy regime that
+// needs consent before tracking, anything but RegimeOther. The Result stored
+// by Middleware is reused when present.
+func ConsentRequired(r *http.Request) bool {
+	return defaultGeolocator.ConsentRequired(r)
+}
+
+func (g *Geolocator) ConsentRequired(r *http.Request) bool {
+	res, ok := ResultFromContext(r.Context())
+	if !ok {
+		res = g.Resolve(r)
+	}
+	if res.Geo == nil && (res.Country == "" || res.Country == "ZZ") {
+		return ConsentForUnknown
+	}
+	return privacyRegion(res.Geo, res.Country) != RegimeOther
+}
+
+// Country codes by privacy regime, a regime per line. The EU line covers
+// records from databases without EU membership. Bump
+// privacyRegimesRevision when changing it.
+var privacyRegionTable = `
+EU: AT BE BG CY CZ DE DK EE ES FI FR GR HR HU IE IT LT LU LV MT NL PL PT RO SE SI SK
+EEA: IS LI NO
+UK: GB GG IM JE
+CH: CH
+`
+
+var regimeCountries = mustParsePrivacyRegions(privacyRegionTable)
+
+func mustParsePrivacyRegions(table string) map[string]string {
+	countries := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(table), "\n") {
+		regime, codes, found := strings.Cut(line, ":")
+		if !found {
+			panic(fmt.Sprintf("webgeo: invalid privacy region line %q", line))
+		}
+		for _, cc := range strings.Fields(codes) {
+			countries[cc] = strings.TrimSpace(regime)
+		}
+	}
+	return countries
+}
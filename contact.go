@@ -0,0 +1,30 @@
+package webgeo
+
+// Contact is the support contact shown to a market, e.g. in footers and on
+// error pages
+type Contact struct {
+	Phone string `json:"phone,omitempty"`
+	Email string `json:"email,omitempty"`
+	// opening hours as displayed, e.g. "Mon-Fri 9:00-17:00", in TimeZone
+	Hours    string `json:"hours,omitempty"`
+	TimeZone string `json:"time_zone,omitempty"` // IANA name
+}
+
+// SupportContacts maps country codes, Market prefixes (e.g. "/de/") or
+// continent codes after ContinentPrefix (e.g. "continent:EU") to the support
+// contact there. A country entry wins over its market, a market over the
+// continent, the "" entry is the default.
+var SupportContacts = map[string]Contact{}
+
+// SupportContact returns the support contact for the visitor
+func SupportContact(res Result) Contact {
+	if c, pres := SupportContacts[res.Country]; pres {
+		return c
+	}
+	for _, m := range Markets {
+		if c, pres := SupportContacts[m.Prefix]; pres && contains(m.Countries, res.Country) {
+			return c
+		}
+	}
+	return ForCountry(SupportContacts, "", res.ContinentCode(), SupportContacts[""])
+}
@@ -0,0 +1,136 @@
+package webgeo
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var countryList, countryMap = mustBuildCountries()
+
+// Country is a row of the embedded geonames country table
+type Country struct {
+	IsoCode      string   `json:"iso_code" yaml:"iso_code"`
+	Name         string   `json:"name" yaml:"name"`
+	Continent    string   `json:"continent" yaml:"continent"` // code, e.g. "EU"
+	TLD          string   `json:"tld,omitempty" yaml:"tld,omitempty"`
+	Currency     string   `json:"currency" yaml:"currency"` // ISO 4217 code
+	CurrencyName string   `json:"currency_name" yaml:"currency_name"`
+	Languages    []string `json:"languages" yaml:"languages"` // most used first
+	CallingCode  string   `json:"calling_code,omitempty" yaml:"calling_code,omitempty"`
+	Population   int      `json:"population" yaml:"population"` // approximate
+	Neighbours   []string `json:"neighbours,omitempty" yaml:"neighbours,omitempty"`
+}
+
+func buildCountries() ([]Country, map[string]*Country, error) {
+	records, err := readCountryInfoTable()
+	if err != nil {
+		return nil, nil, err
+	}
+	list := make([]Country, 0, len(records))
+	for _, r := range records {
+		c := Country{
+			IsoCode:      r[0],
+			Name:         strings.TrimSpace(r[1]),
+			Continent:    r[2],
+			TLD:          r[3],
+			Currency:     r[4],
+			CurrencyName: strings.TrimSpace(r[5]),
+			Languages:    splitList(r[6]),
+			Neighbours:   splitList(r[9]),
+		}
+		if r[7] != "" {
+			c.CallingCode = "+" + r[7]
+		}
+		if c.Population, err = strconv.Atoi(r[8]); err != nil {
+			return nil, nil, err
+		}
+		list = append(list, c)
+	}
+	m := make(map[string]*Country)
+	for i := range list {
+		m[list[i].IsoCode] = &list[i]
+	}
+	return list, m, nil
+}
+
+func mustBuildCountries() ([]Country, map[string]*Country) {
+	l, m, err := buildCountries()
+	if err != nil {
+		panic(err)
+	}
+	return l, m
+}
+
+func splitList(s string) []string {
+	l := []string{}
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			l = append(l, e)
+		}
+	}
+	return l
+}
+
+// CountryByCode returns the country with the ISO code cc, nil if unknown
+func CountryByCode(cc string) *Country {
+	c, pres := countryMap[strings.ToUpper(cc)]
+	if !pres {
+		return nil
+	}
+	cp := c.clone()
+	return &cp
+}
+
+// AllCountries returns all countries of the table ordered by ISO code
+func AllCountries() []Country {
+	l := make([]Country, len(countryList))
+	for i := range countryList {
+		l[i] = countryList[i].clone()
+	}
+	sort.Slice(l, func(i, j int) bool { return l[i].IsoCode < l[j].IsoCode })
+	return l
+}
+
+// copy that doesn't share the slices with the table
+func (c Country) clone() Country {
+	c.Languages = append([]string{}, c.Languages...)
+	c.Neighbours = append([]string{}, c.Neighbours...)
+	return c
+}
+
+func continentOf(cc string) string {
+	if c, pres := countryMap[cc]; pres {
+		return c.Continent
+	}
+	return ""
+}
+
+// CurrencyFor returns the ISO 4217 code and name of the currency used in the
+// country, e.g. "PLN", "Zloty". Empty strings for unknown countries.
+func CurrencyFor(cc string) (code, name string) {
+	if c, pres := countryMap[strings.ToUpper(cc)]; pres {
+		return c.Currency, c.CurrencyName
+	}
+	return "", ""
+}
+
+// CallingCodeFor returns the international dialing prefix of the country,
+// e.g. "+48". Countries sharing a plan get the same code ("+1" for US, CA and
+// the Caribbean). Empty for unknown countries.
+func CallingCodeFor(cc string) string {
+	if c, pres := countryMap[strings.ToUpper(cc)]; pres {
+		return c.CallingCode
+	}
+	return ""
+}
+
+// TLDFor returns the country code top-level domain with the leading dot,
+// e.g. ".pl". Not always the ISO code (".uk" for GB), empty when the
+// country has none.
+func TLDFor(cc string) string {
+	if c, pres := countryMap[strings.ToUpper(cc)]; pres {
+		return c.TLD
+	}
+	return ""
+}
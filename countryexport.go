@@ -0,0 +1,45 @@
+package webgeo
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var countryColumns = []string{"iso_code", "name", "continent", "tld", "currency", "currency_name", "languages", "calling_code", "population", "neighbours"}
+
+// ExportCountries writes AllCountries to w as "json", "csv" or "yaml", so
+// other tools use the same country table as webgeo instead of their own
+// copy of geonames. CSV has a header row and comma separated lists in
+// quoted fields. See DataManifest for the revision of the table.
+func ExportCountries(w io.Writer, format string) error {
+	countries := AllCountries()
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(countries)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(countries); err != nil {
+			return err
+		}
+		return enc.Close()
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write(countryColumns)
+		for _, c := range countries {
+			cw.Write([]string{c.IsoCode, c.Name, c.Continent, c.TLD, c.Currency, c.CurrencyName,
+				strings.Join(c.Languages, ","), c.CallingCode, strconv.Itoa(c.Population), strings.Join(c.Neighbours, ",")})
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return fmt.Errorf("Unknown format %q, want json, csv or yaml", format)
+}
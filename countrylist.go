@@ -0,0 +1,37 @@
+package webgeo
+
+import (
+	"sort"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+type CountryName struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// SortedCountryList returns the countries of the embedded table with names
+// localized to in, ordered by the collation rules of in (so "Č" sorts after
+// "C" for Czech users, "Ö" at the end for Swedish ones). Names fall back to
+// English where the locale data has none.
+func SortedCountryList(in language.Tag) []CountryName {
+	namer := display.Regions(in)
+	list := make([]CountryName, 0, len(countryList))
+	for _, c := range countryList {
+		name := c.Name
+		if region, err := language.ParseRegion(c.IsoCode); err == nil && namer != nil {
+			if n := namer.Name(region); n != "" {
+				name = n
+			}
+		}
+		list = append(list, CountryName{c.IsoCode, name})
+	}
+	c := collate.New(in)
+	sort.SliceStable(list, func(i, j int) bool {
+		return c.CompareString(list[i].Name, list[j].Name) < 0
+	})
+	return list
+}
@@ -0,0 +1,123 @@
+package webgeo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+)
+
+// LookupCtx returns the GeoRecord of ip, nil when the lookup failed. An IP
+// missing from the database gives a GeoRecord with an empty Cc. ip may
+// include a port. The error is a *DBError when the database is broken.
+// When ctx is done before the lookup completes, e.g. while the database is
+// still being downloaded, ctx.Err() is returned and the lookup finishes in
+// the background to fill the cache.
+func LookupCtx(ctx context.Context, ip string) (*GeoRecord, error) {
+	return defaultGeolocator.LookupCtx(ctx, ip)
+}
+
+func (g *Geolocator) LookupCtx(ctx context.Context, ip string) (*GeoRecord, error) {
+	ipS := remoteIP(ip)
+	if ipS == "" {
+		return nil, fmt.Errorf("Invalid IP %q", ip)
+	}
+	return g.lookupCtx(ctx, ipS)
+}
+
+func (g *Geolocator) lookupCtx(ctx context.Context, ipS string) (*GeoRecord, error) {
+	var e CacheEntry
+	err := g.withContext(ctx, ipS, func() {
+		e = g.lookupEntry(ipS)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e.Geo, e.Err
+}
+
+// CalcCountryAndLangsCtx is CalcCountryAndLangs honoring cancellation and
+// deadlines of ctx, see LookupCtx. With a broken database the error is a
+// *DBError, along with the country and languages CalcCountryAndLangs gives.
+func CalcCountryAndLangsCtx(ctx context.Context, r *http.Request) (string, []string, error) {
+	return defaultGeolocator.CalcCountryAndLangsCtx(ctx, r)
+}
+
+func (g *Geolocator) CalcCountryAndLangsCtx(ctx context.Context, r *http.Request) (string, []string, error) {
+	res, err := g.resolveCtx(ctx, r, g.calcCountryAndLangs)
+	return res.Country, res.Langs, err
+}
+
+// ResolveCtx is Resolve honoring ctx, with the errors of
+// CalcCountryAndLangsCtx. Derive everything else about the request from
+// its Result, not from further calls without ctx.
+func ResolveCtx(ctx context.Context, r *http.Request) (Result, error) {
+	return defaultGeolocator.ResolveCtx(ctx, r)
+}
+
+func (g *Geolocator) ResolveCtx(ctx context.Context, r *http.Request) (Result, error) {
+	return g.resolveCtx(ctx, r, g.resolve)
+}
+
+// Run calc of r until ctx is done. Of the lookup errors only a broken
+// database is reported, as for CalcCountryAndLangs the rest give ZZ.
+func (g *Geolocator) resolveCtx(ctx context.Context, r *http.Request, calc func(r *http.Request) (Result, error)) (Result, error) {
+	var res Result
+	var lookupErr error
+	err := g.withContext(ctx, g.ClientIP(r), func() {
+		res, lookupErr = calc(r)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	var dbErr *DBError
+	if errors.As(lookupErr, &dbErr) {
+		return res, lookupErr
+	}
+	return res, nil
+}
+
+// LookupResult is the LookupBatch Result of addr with the error of
+// LookupCtx, for services answering for an IP instead of a request. addr
+// is looked up once, also WithoutCache. An invalid addr gives ZZ and
+// ErrNoClientIP.
+func LookupResult(ctx context.Context, addr netip.Addr) (Result, error) {
+	return defaultGeolocator.LookupResult(ctx, addr)
+}
+
+func (g *Geolocator) LookupResult(ctx context.Context, addr netip.Addr) (Result, error) {
+	if !addr.IsValid() {
+		return geoResult(newGeoEntry(nil, nil)), ErrNoClientIP
+	}
+	ipS := addr.Unmap().String()
+	var e CacheEntry
+	if err := g.withContext(ctx, ipS, func() { e = g.lookupEntry(ipS) }); err != nil {
+		return Result{}, err
+	}
+	return geoResult(e), e.Err
+}
+
+// Run the lookup f of ipS until ctx is done. Cache hits and IPs that
+// don't need a lookup don't start a goroutine.
+func (g *Geolocator) withContext(ctx context.Context, ipS string, f func()) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	_, cached := g.cache.Get(g.cacheKey(ipS))
+	if cached || ipS == "" {
+		f()
+		return nil
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,90 @@
+package webgeo
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// path of a database file that exists but can't be opened
+func brokenDB(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "broken.mmdb")
+	if err := os.WriteFile(path, []byte("not a database"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLookupCtxBrokenDB(t *testing.T) {
+	path := brokenDB(t)
+	for name, g := range map[string]*Geolocator{
+		"cached":        New(WithDBPath(path)),
+		"without cache": New(WithDBPath(path), WithoutCache()),
+		"no negative":   New(WithDBPath(path), WithNegativeCacheTTL(-1)),
+		"network keyed": New(WithDBPath(path), WithCacheByPrefix(true)),
+	} {
+		for i := 0; i < 2; i++ {
+			geo, err := g.LookupCtx(context.Background(), "192.0.2.1")
+			var dbErr *DBError
+			if geo != nil || !errors.As(err, &dbErr) {
+				t.Errorf("%s: LookupCtx = %v, %v, want a *DBError", name, geo, err)
+			}
+		}
+	}
+}
+
+func TestCalcCountryAndLangsCtxBrokenDB(t *testing.T) {
+	g := New(WithDBPath(brokenDB(t)), WithoutCache())
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	r.Header.Set("Accept-Language", "de")
+	country, langs, err := g.CalcCountryAndLangsCtx(context.Background(), r)
+	var dbErr *DBError
+	if !errors.As(err, &dbErr) || country != "ZZ" || len(langs) == 0 || langs[0] != "de" {
+		t.Errorf("CalcCountryAndLangsCtx = %q, %v, %v, want ZZ, de and a *DBError", country, langs, err)
+	}
+	if m := g.CacheStats().Misses; m != 1 {
+		t.Errorf("%d lookups, want 1", m)
+	}
+}
+
+func TestResolveCtxBrokenDB(t *testing.T) {
+	g := New(WithDBPath(brokenDB(t)), WithoutCache())
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	r.Header.Set("Accept-Language", "de")
+	res, err := g.ResolveCtx(context.Background(), r)
+	var dbErr *DBError
+	if !errors.As(err, &dbErr) || res.Country != "ZZ" || len(res.Langs) == 0 || res.Langs[0] != "de" {
+		t.Errorf("ResolveCtx = %+v, %v, want ZZ, de and a *DBError", res, err)
+	}
+	if m := g.CacheStats().Misses; m != 1 {
+		t.Errorf("%d lookups, want 1", m)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := g.ResolveCtx(ctx, r); err != context.Canceled {
+		t.Errorf("ResolveCtx with a canceled context: %v", err)
+	}
+}
+
+func TestLookupResult(t *testing.T) {
+	g := New(WithDBPath(brokenDB(t)), WithoutCache())
+	res, err := g.LookupResult(context.Background(), netip.MustParseAddr("192.0.2.1"))
+	var dbErr *DBError
+	if !errors.As(err, &dbErr) || res.Country != "ZZ" || res.Geo != nil {
+		t.Errorf("LookupResult = %+v, %v, want ZZ and a *DBError", res, err)
+	}
+	if m := g.CacheStats().Misses; m != 1 {
+		t.Errorf("%d lookups, want 1", m)
+	}
+	if _, err := g.LookupResult(context.Background(), netip.Addr{}); err != ErrNoClientIP {
+		t.Errorf("LookupResult of no address: %v, want ErrNoClientIP", err)
+	}
+}
@@ -0,0 +1,142 @@
+package webgeo
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	geoip2 "github.com/oschwald/geoip2-golang"
+)
+
+// DBInMemory reads the whole database into memory instead of mmapping it.
+// Costs the file size in heap but avoids page faults on a cold page cache.
+// Takes effect the next time the database is opened, see CloseDB.
+var DBInMemory = false
+
+// ASNDBPath is the location of an optional GeoLite2 ASN database. When set,
+// GeoRecord also carries the autonomous system of the IP.
+var ASNDBPath = ""
+
+// A database reader shared by all lookups, opened on first use
+type sharedDB struct {
+	mutex  sync.RWMutex
+	reader *geoip2.Reader
+	path   string
+
+	// the last failure to open path, not retried before retryAt so a
+	// missing database isn't downloaded again for every request
+	err        error
+	failedPath string
+	retryAt    time.Time
+	retry      func() time.Duration
+
+	// build time of the last database opened, to count the times a
+	// different one replaced it, read atomically
+	buildEpoch uint
+	swaps      uint64
+}
+
+func newSharedDB(retry func() time.Duration) *sharedDB {
+	return &sharedDB{retry: retry}
+}
+
+// How often the database was swapped for another one
+func (s *sharedDB) swapCount() uint64 {
+	return atomic.LoadUint64(&s.swaps)
+}
+
+// Run f with the reader of the database at path, opening it first if needed
+// or if the path changed since.
+func (s *sharedDB) with(path string, open func(string) (*geoip2.Reader, error), f func(db *geoip2.Reader) error) error {
+	s.mutex.RLock()
+	if s.reader != nil && s.path == path {
+		defer s.mutex.RUnlock()
+		return f(s.reader)
+	}
+	s.mutex.RUnlock()
+
+	s.mutex.Lock()
+	if s.reader == nil || s.path != path {
+		if s.err != nil && s.failedPath == path && time.Now().Before(s.retryAt) {
+			s.mutex.Unlock()
+			return s.err
+		}
+		db, err := open(path)
+		if err != nil {
+			s.err, s.failedPath, s.retryAt = err, path, time.Now().Add(s.retry())
+			s.mutex.Unlock()
+			return err
+		}
+		if s.reader != nil {
+			s.reader.Close()
+		}
+		epoch := db.Metadata().BuildEpoch
+		swapped := s.buildEpoch != 0 && epoch != s.buildEpoch
+		s.reader, s.path, s.err, s.buildEpoch = db, path, nil, epoch
+		if swapped {
+			atomic.AddUint64(&s.swaps, 1)
+		}
+	}
+	s.mutex.Unlock()
+	return s.with(path, open, f)
+}
+
+func (s *sharedDB) close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.err = nil
+	if s.reader == nil {
+		return nil
+	}
+	err := s.reader.Close()
+	s.reader, s.path = nil, ""
+	return err
+}
+
+func (g *Geolocator) withDB(f func(db *geoip2.Reader) error) error {
+	return g.cityDB.with(g.cityPath(), g.openDB, f)
+}
+
+func (g *Geolocator) withASNDB(f func(db *geoip2.Reader) error) error {
+	return g.asnDB.with(g.asnPath(), g.openReader, f)
+}
+
+// CloseDB releases the shared database readers. The next lookup opens them again.
+func CloseDB() error {
+	return defaultGeolocator.Close()
+}
+
+func (g *Geolocator) openDB(mmdbfile string) (*geoip2.Reader, error) {
+	if _, err := os.Stat(mmdbfile); err != nil {
+		g.logf("%s does not exist. Checking for gz...", mmdbfile)
+		if _, err := os.Stat(mmdbfile + ".gz"); err != nil {
+			g.logf("%s.gz does not exist. Downloading...", mmdbfile)
+			exec.Command("wget", "-N", "-P", filepath.Dir(mmdbfile), "http://geolite.maxmind.com/download/geoip/database/GeoLite2-City.mmdb.gz").Output()
+		}
+		if _, err := os.Stat(mmdbfile + ".gz"); err != nil {
+			return nil, fmt.Errorf("Could not download %s.gz", mmdbfile)
+		}
+		g.logf("Unzip %s.gz", mmdbfile)
+		exec.Command("gunzip", mmdbfile+".gz").Output()
+		if _, err := os.Stat(mmdbfile); err != nil {
+			return nil, fmt.Errorf("Could not unzip %s.gz", mmdbfile)
+		}
+	}
+
+	return g.openReader(mmdbfile)
+}
+
+func (g *Geolocator) openReader(mmdbfile string) (*geoip2.Reader, error) {
+	if g.dbInMemory() {
+		b, err := os.ReadFile(mmdbfile)
+		if err != nil {
+			return nil, err
+		}
+		return geoip2.FromBytes(b)
+	}
+	return geoip2.Open(mmdbfile)
+}
@@ -0,0 +1,178 @@
+package webgeo
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"time"
+
+	geoip2 "github.com/oschwald/geoip2-golang"
+)
+
+const maxLastErrors = 20
+const maxTopCountries = 20
+
+var diagMutex = sync.Mutex{}
+var lastErrors = []ErrorEntry{}
+var countryHits = make(map[string]int64)
+
+// Diagnostics is a snapshot of the internal state, dumped as JSON when
+// debugging production incidents.
+type Diagnostics struct {
+	Time         time.Time      `json:"time"`
+	Config       DiagConfig     `json:"config"`
+	Database     DBInfo         `json:"database"`
+	Data         []DataSet      `json:"data"`
+	CacheEntries int            `json:"cache_entries"`
+	Cache        CacheCounts    `json:"cache"`
+	LastErrors   []ErrorEntry   `json:"last_errors"`
+	TopCountries []CountryCount `json:"top_countries"`
+}
+
+type DiagConfig = ConfigSnapshot
+
+// DBInfo describes the database file. Error is set when it can't be opened.
+type DBInfo struct {
+	Path        string    `json:"path"`
+	Type        string    `json:"type,omitempty"`
+	BuildTime   time.Time `json:"build_time,omitempty"`
+	Description string    `json:"description,omitempty"`
+	IPVersion   uint      `json:"ip_version,omitempty"`
+	NodeCount   uint      `json:"node_count,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+type ErrorEntry struct {
+	Time  time.Time `json:"time"`
+	Error string    `json:"error"`
+}
+
+type CountryCount struct {
+	Country string `json:"country"`
+	Count   int64  `json:"count"`
+}
+
+func recordError(err error) {
+	diagMutex.Lock()
+	defer diagMutex.Unlock()
+	lastErrors = append(lastErrors, ErrorEntry{time.Now(), err.Error()})
+	if len(lastErrors) > maxLastErrors {
+		lastErrors = lastErrors[len(lastErrors)-maxLastErrors:]
+	}
+}
+
+func countCountry(cc string) {
+	diagMutex.Lock()
+	countryHits[cc]++
+	diagMutex.Unlock()
+}
+
+// Read database metadata without triggering a download
+func readDBInfo(path string) DBInfo {
+	info := DBInfo{Path: path}
+	db, err := geoip2.Open(path)
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	defer db.Close()
+	info.fill(db)
+	return info
+}
+
+func (info *DBInfo) fill(db *geoip2.Reader) {
+	m := db.Metadata()
+	info.Type = m.DatabaseType
+	info.BuildTime = time.Unix(int64(m.BuildEpoch), 0).UTC()
+	info.Description = m.Description["en"]
+	info.IPVersion = m.IPVersion
+	info.NodeCount = m.NodeCount
+}
+
+func Dump() Diagnostics {
+	d := Diagnostics{
+		Time:     time.Now(),
+		Config:   EffectiveConfig(),
+		Database: readDBInfo(DBPath),
+		Data:     DataManifest(),
+	}
+	d.Cache = CacheStats()
+	d.CacheEntries = d.Cache.Size
+
+	diagMutex.Lock()
+	d.LastErrors = append([]ErrorEntry{}, lastErrors...)
+	for cc, n := range countryHits {
+		d.TopCountries = append(d.TopCountries, CountryCount{cc, n})
+	}
+	diagMutex.Unlock()
+	sort.Slice(d.TopCountries, func(i, j int) bool {
+		if d.TopCountries[i].Count != d.TopCountries[j].Count {
+			return d.TopCountries[i].Count > d.TopCountries[j].Count
+		}
+		return d.TopCountries[i].Country < d.TopCountries[j].Country
+	})
+	if len(d.TopCountries) > maxTopCountries {
+		d.TopCountries = d.TopCountries[:maxTopCountries]
+	}
+	return d
+}
+
+func WriteDiagnostics(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(Dump())
+}
+
+// DiagnosticsHandler serves the diagnostic dump. Mount it on an admin-only
+// route, it exposes configuration and error messages.
+func DiagnosticsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := WriteDiagnostics(w); err != nil {
+			log.Printf("webgeo: could not write diagnostics: %v", err)
+		}
+	})
+}
+
+// DumpOnSignal writes the diagnostic dump to path every time one of the
+// signals is received. Call the returned function to stop listening.
+func DumpOnSignal(path string, sig ...os.Signal) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, sig...)
+	go func() {
+		for {
+			select {
+			case <-ch:
+				if err := dumpToFile(path); err != nil {
+					log.Printf("webgeo: diagnostic dump failed: %v", err)
+				} else {
+					log.Printf("webgeo: diagnostics written to %s", path)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
+
+func dumpToFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteDiagnostics(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,11 @@
+//go:build unix
+
+package webgeo
+
+import "syscall"
+
+// DumpOnSIGUSR2 writes the diagnostic dump to path on every SIGUSR2,
+// e.g. `kill -USR2 <pid>`
+func DumpOnSIGUSR2(path string) (stop func()) {
+	return DumpOnSignal(path, syscall.SIGUSR2)
+}
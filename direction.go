@@ -0,0 +1,67 @@
+package webgeo
+
+import (
+	"fmt"
+	"html/template"
+
+	"golang.org/x/text/language"
+)
+
+// Direction is the writing direction of a language, the value of the HTML
+// dir attribute
+type Direction string
+
+const (
+	LTR Direction = "ltr"
+	RTL Direction = "rtl"
+)
+
+// scripts written right to left
+var rtlScripts = map[string]bool{
+	"Adlm": true, "Arab": true, "Hebr": true, "Mand": true, "Nkoo": true,
+	"Rohg": true, "Samr": true, "Syrc": true, "Thaa": true,
+}
+
+// DirectionOf returns the direction of the script tag is most likely written
+// in, e.g. RTL for ar, he, fa, ur and pa-Arab
+func DirectionOf(tag language.Tag) Direction {
+	script, _ := tag.Script()
+	if rtlScripts[script.String()] {
+		return RTL
+	}
+	return LTR
+}
+
+// Directions of res.Langs, in the same order
+func (res Result) Directions() []Direction {
+	dirs := make([]Direction, len(res.Langs))
+	for i, l := range res.Langs {
+		dirs[i] = LTR
+		if t, err := language.Parse(l); err == nil {
+			dirs[i] = DirectionOf(t)
+		}
+	}
+	return dirs
+}
+
+// Dir is the direction of the preferred language, LTR when there is none
+func (res Result) Dir() Direction {
+	if dirs := res.Directions(); len(dirs) > 0 {
+		return dirs[0]
+	}
+	return LTR
+}
+
+// HTMLAttrs announces the preferred language and its direction to browsers,
+// screen readers and search engines, for the html element of a template
+// executed with res:
+//
+//	<html {{.HTMLAttrs}}>
+//
+// gives <html lang="ar" dir="rtl">. Empty when there is no language.
+func (res Result) HTMLAttrs() template.HTMLAttr {
+	if len(res.Langs) == 0 {
+		return ""
+	}
+	return template.HTMLAttr(fmt.Sprintf(`lang="%s" dir="%s"`, template.HTMLEscapeString(res.Langs[0]), res.Dir()))
+}
@@ -0,0 +1,42 @@
+package webgeo
+
+import (
+	"fmt"
+	"math"
+)
+
+const earthRadiusKm = 6371.0
+
+// Distance returns the great-circle distance between two lookups in km
+func Distance(a, b *GeoRecord) (float64, error) {
+	if !a.hasLocation() || !b.hasLocation() {
+		return 0, fmt.Errorf("Location unknown")
+	}
+	return haversine(a.Latitude, a.Longitude, b.Latitude, b.Longitude), nil
+}
+
+// DistanceTo returns the great-circle distance in km from the record to the
+// given coordinates, e.g. to pick the nearest regional endpoint. g may be
+// nil, as returned by Lookup when the lookup failed.
+func (g *GeoRecord) DistanceTo(lat, lon float64) (float64, error) {
+	if g == nil {
+		return 0, fmt.Errorf("Location unknown")
+	}
+	if !g.hasLocation() {
+		return 0, fmt.Errorf("Location of %s unknown", g.Ip)
+	}
+	return haversine(g.Latitude, g.Longitude, lat, lon), nil
+}
+
+func (g *GeoRecord) hasLocation() bool {
+	return g != nil && (g.Latitude != 0 || g.Longitude != 0)
+}
+
+func haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
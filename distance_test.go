@@ -0,0 +1,24 @@
+package webgeo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistanceTo(t *testing.T) {
+	warsaw := &GeoRecord{Ip: "192.0.2.1", Latitude: 52.2297, Longitude: 21.0122}
+	d, err := warsaw.DistanceTo(52.5200, 13.4050) // Berlin
+	if err != nil || math.Abs(d-517) > 5 {
+		t.Errorf("Warsaw to Berlin = %.0f km, %v", d, err)
+	}
+	if _, err := (&GeoRecord{Ip: "192.0.2.1"}).DistanceTo(0, 1); err == nil {
+		t.Errorf("no error without a location")
+	}
+	var unknown *GeoRecord
+	if _, err := unknown.DistanceTo(0, 1); err == nil {
+		t.Errorf("no error for a nil record")
+	}
+	if _, err := Distance(warsaw, nil); err == nil {
+		t.Errorf("no error for a nil record")
+	}
+}
@@ -0,0 +1,112 @@
+package webgeo
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// What Enricher.Enqueue does when the queue is full
+type OverflowPolicy int
+
+const (
+	// Drop the new item and count it in Dropped
+	DropOnFull OverflowPolicy = iota
+	// Block the caller until a worker frees a slot
+	BlockOnFull
+)
+
+// Enricher geolocates IPs asynchronously for logging pipelines. Items go
+// through a bounded queue drained by a fixed number of workers, so under a
+// traffic spike memory stays bounded and the producer is either never slowed
+// down (DropOnFull) or throttled (BlockOnFull) - its choice.
+type Enricher struct {
+	g       *Geolocator
+	queue   chan enrichItem
+	policy  OverflowPolicy
+	sink    func(v interface{}, geo *GeoRecord)
+	dropped uint64
+	mutex   sync.RWMutex
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+type enrichItem struct {
+	ip string
+	v  interface{}
+}
+
+// NewEnricher starts workers that look up each enqueued IP and pass the
+// payload with its GeoRecord to sink. geo is nil when the lookup failed.
+// sink is called concurrently from the workers. Lookups go through the
+// cache, repeated IPs cost one database lookup.
+func NewEnricher(queueSize, workers int, policy OverflowPolicy, sink func(v interface{}, geo *GeoRecord)) *Enricher {
+	return defaultGeolocator.NewEnricher(queueSize, workers, policy, sink)
+}
+
+func (g *Geolocator) NewEnricher(queueSize, workers int, policy OverflowPolicy, sink func(v interface{}, geo *GeoRecord)) *Enricher {
+	if workers < 1 {
+		workers = 1
+	}
+	e := &Enricher{
+		g:      g,
+		queue:  make(chan enrichItem, queueSize),
+		policy: policy,
+		sink:   sink,
+	}
+	e.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go e.work()
+	}
+	return e
+}
+
+func (e *Enricher) work() {
+	defer e.wg.Done()
+	for it := range e.queue {
+		e.sink(it.v, e.g.lookupEntry(it.ip).Geo)
+	}
+}
+
+// Enqueue v for enrichment with the location of ip. Returns false when the
+// item was dropped because the queue is full or the Enricher is closed.
+func (e *Enricher) Enqueue(ip string, v interface{}) bool {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	if e.closed {
+		atomic.AddUint64(&e.dropped, 1)
+		return false
+	}
+	it := enrichItem{remoteIP(ip), v}
+	if e.policy == BlockOnFull {
+		e.queue <- it
+		return true
+	}
+	select {
+	case e.queue <- it:
+		return true
+	default:
+		atomic.AddUint64(&e.dropped, 1)
+		return false
+	}
+}
+
+// Dropped returns the number of items dropped so far
+func (e *Enricher) Dropped() uint64 {
+	return atomic.LoadUint64(&e.dropped)
+}
+
+// Pending returns the number of items waiting in the queue
+func (e *Enricher) Pending() int {
+	return len(e.queue)
+}
+
+// Close stops accepting items and waits until the queue is drained
+func (e *Enricher) Close() {
+	e.mutex.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.queue)
+	}
+	e.mutex.Unlock()
+	e.wg.Wait()
+}
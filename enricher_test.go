@@ -0,0 +1,37 @@
+package webgeo
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestEnricherUsesCache(t *testing.T) {
+	g := New(WithDBPath(brokenDB(t)))
+	g.cache.Set("192.0.2.1", newGeoEntry(&GeoRecord{Ip: "192.0.2.1", Cc: "DE"}, nil))
+	var mutex sync.Mutex
+	got := map[string]string{}
+	e := g.NewEnricher(10, 2, BlockOnFull, func(v interface{}, geo *GeoRecord) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		got[v.(string)] = "nil"
+		if geo != nil {
+			got[v.(string)] = geo.Cc
+		}
+	})
+	e.Enqueue("192.0.2.1:4321", "cached")
+	e.Enqueue("192.0.2.9", "broken")
+	e.Enqueue("@", "no ip")
+	e.Close()
+	want := map[string]string{"cached": "DE", "broken": "nil", "no ip": "nil"}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s: %s, want %s", k, got[k], v)
+		}
+	}
+	if c := g.CacheStats(); c.Hits != 1 {
+		t.Errorf("%d cache hits, want 1", c.Hits)
+	}
+	if e.Enqueue("192.0.2.1", "closed") || e.Dropped() != 1 {
+		t.Errorf("Enqueue after Close accepted")
+	}
+}
@@ -0,0 +1,38 @@
+package webgeo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrNoClientIP means the request has no usable client IP, e.g. a malformed
+// RemoteAddr or a unix socket listener
+var ErrNoClientIP = errors.New("No usable client IP")
+
+// DBError means the database could not be opened or read: the geo
+// subsystem is broken, as opposed to the location being unknown
+type DBError struct {
+	Path string
+	Err  error
+}
+
+func (e *DBError) Error() string {
+	return fmt.Sprintf("Database %s: %v", e.Path, e.Err)
+}
+
+func (e *DBError) Unwrap() error {
+	return e.Err
+}
+
+// CalcCountryAndLangsE is Resolve reporting why the location is unknown.
+// The error is ErrNoClientIP or a *DBError; an IP that is simply not in the
+// database gives country "ZZ" and no error. The Result is filled either way,
+// so callers can log the error and carry on with it.
+func CalcCountryAndLangsE(r *http.Request) (Result, error) {
+	return defaultGeolocator.CalcCountryAndLangsE(r)
+}
+
+func (g *Geolocator) CalcCountryAndLangsE(r *http.Request) (Result, error) {
+	return g.resolve(r)
+}
@@ -0,0 +1,36 @@
+package webgeo
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCalcCountryAndLangsEBrokenDB(t *testing.T) {
+	path := brokenDB(t)
+	for name, g := range map[string]*Geolocator{
+		"cached":        New(WithDBPath(path)),
+		"without cache": New(WithDBPath(path), WithoutCache()),
+		"no negative":   New(WithDBPath(path), WithNegativeCacheTTL(-1)),
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "192.0.2.1:4321"
+		r.Header.Set("Accept-Language", "de")
+		var dbErr *DBError
+		res, err := g.CalcCountryAndLangsE(r)
+		if !errors.As(err, &dbErr) || res.Country != "ZZ" || len(res.Langs) != 1 {
+			t.Errorf("%s: CalcCountryAndLangsE = %+v, %v, want ZZ [de] and a *DBError", name, res, err)
+		}
+		if _, err := g.Langs(r); !errors.As(err, &dbErr) {
+			t.Errorf("%s: Langs error %v, want a *DBError", name, err)
+		}
+	}
+}
+
+func TestCalcCountryAndLangsENoClientIP(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "@"
+	if _, err := New(WithDBPath(brokenDB(t))).CalcCountryAndLangsE(r); err != ErrNoClientIP {
+		t.Errorf("error %v, want ErrNoClientIP", err)
+	}
+}
@@ -0,0 +1,79 @@
+package webgeo
+
+import (
+	"context"
+	"hash/fnv"
+	"net/http"
+	"strings"
+)
+
+type bucketsKey struct{}
+
+// Bucket assigns the country of record to one of buckets buckets of the
+// experiment, so a geo-stratified A/B test puts every visitor of a country
+// in the same arm. The assignment only depends on the experiment name and
+// the country code, "ZZ" when record is nil: it is the FNV-1a 32-bit hash of
+// the name, a zero byte and the code, modulo buckets, which other services
+// can reproduce. Returns 0 when buckets is less than 1.
+func Bucket(record *GeoRecord, experiment string, buckets int) int {
+	cc := "ZZ"
+	if record != nil && record.Cc != "" {
+		cc = record.Cc
+	}
+	return countryBucket(cc, experiment, buckets)
+}
+
+// Bucket is the package Bucket for the country of res
+func (res Result) Bucket(experiment string, buckets int) int {
+	return countryBucket(res.Country, experiment, buckets)
+}
+
+func countryBucket(cc, experiment string, buckets int) int {
+	if buckets < 1 {
+		return 0
+	}
+	if cc == "" {
+		cc = "ZZ"
+	}
+	h := fnv.New32a()
+	h.Write([]byte(experiment))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.ToUpper(cc)))
+	return int(h.Sum32() % uint32(buckets))
+}
+
+// Experiments assigns each request to a bucket of each experiment, given
+// as name to number of buckets, and passes it to next with the assignments
+// in its context, see BucketFromContext. The Result stored by Middleware is
+// reused when present. Requests with SkipMethods are only assigned when the
+// location is already cached.
+func Experiments(next http.Handler, experiments map[string]int) http.Handler {
+	return defaultGeolocator.Experiments(next, experiments)
+}
+
+func (g *Geolocator) Experiments(next http.Handler, experiments map[string]int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, ok := ResultFromContext(r.Context())
+		if !ok {
+			res, ok = g.MiddlewareResolve(r)
+		}
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		buckets := make(map[string]int, len(experiments))
+		for name, n := range experiments {
+			buckets[name] = res.Bucket(name, n)
+		}
+		AddVary(w.Header(), GeoVary)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), bucketsKey{}, buckets)))
+	})
+}
+
+// BucketFromContext returns the bucket of experiment assigned by
+// Experiments. ok is false when the request wasn't assigned.
+func BucketFromContext(ctx context.Context, experiment string) (bucket int, ok bool) {
+	buckets, _ := ctx.Value(bucketsKey{}).(map[string]int)
+	bucket, ok = buckets[experiment]
+	return bucket, ok
+}
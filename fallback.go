@@ -0,0 +1,53 @@
+package webgeo
+
+import "net"
+
+// Fallback locates IPs the database has no answer for, e.g. a remote API
+// like ipinfogeo. Locate returns nil when the location is unknown. Its
+// answers are cached like the ones of the database.
+type Fallback interface {
+	Locate(ip string) (*GeoRecord, error)
+}
+
+// FallbackProvider is asked when the database is absent or doesn't know
+// the country of an IP, nil by default
+var FallbackProvider Fallback
+
+// WithFallbackProvider sets the Fallback of g instead of FallbackProvider
+func WithFallbackProvider(f Fallback) Option {
+	return func(g *Geolocator) { g.fallback = f }
+}
+
+func (g *Geolocator) fallbackProvider() Fallback {
+	if g.fallback != nil {
+		return g.fallback
+	}
+	return FallbackProvider
+}
+
+// Fill the gaps of the database lookups of ips with the fallback. Its
+// failures leave the database answer, so an unreachable API doesn't hide
+// a missing database.
+func (g *Geolocator) fallbackAll(ips []net.IP, geos []*GeoRecord, errs []error) {
+	f := g.fallbackProvider()
+	if f == nil {
+		return
+	}
+	for i, ip := range ips {
+		if errs[i] == nil && geos[i] != nil && geos[i].Cc != "" {
+			continue
+		}
+		geo, err := f.Locate(ip.String())
+		if err != nil {
+			recordError(err)
+			continue
+		}
+		if geo == nil || geo.Cc == "" {
+			continue
+		}
+		if StrictCodes {
+			validateCodes(geo)
+		}
+		geos[i], errs[i] = geo, nil
+	}
+}
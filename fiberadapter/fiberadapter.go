@@ -0,0 +1,116 @@
+// Package fiberadapter plugs webgeo into Fiber and plain fasthttp, which
+// don't use *http.Request:
+//
+//	app := fiber.New()
+//	app.Use(fiberadapter.Middleware(nil))
+//	app.Get("/", func(c *fiber.Ctx) error {
+//		res, _ := fiberadapter.FromContext(c.Context())
+//		return c.SendString(res.Country)
+//	})
+package fiberadapter
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/seckiss/webgeo"
+	"github.com/valyala/fasthttp"
+	"golang.org/x/text/language"
+)
+
+// Key under which the webgeo.Result is stored with SetUserValue, which is
+// also what Fiber's c.Locals reads
+const Key = "geo"
+
+// Middleware resolves each request with g, or the package settings when g is
+// nil, and stores the webgeo.Result under Key and in c.UserContext(), see
+// webgeo.ResultFromContext. Requests with webgeo.SkipMethods only get a
+// Result when the location is already cached.
+func Middleware(g *webgeo.Geolocator) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if res, ok := resolve(g, c.Context()); ok {
+			c.SetUserContext(webgeo.NewContext(c.UserContext(), res))
+		}
+		return c.Next()
+	}
+}
+
+// Wrap is Middleware for plain fasthttp handlers
+func Wrap(g *webgeo.Geolocator, next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		resolve(g, ctx)
+		next(ctx)
+	}
+}
+
+func resolve(g *webgeo.Geolocator, ctx *fasthttp.RequestCtx) (webgeo.Result, bool) {
+	r := Request(ctx)
+	var res webgeo.Result
+	var ok bool
+	if g != nil {
+		res, ok = g.MiddlewareResolve(r)
+	} else {
+		res, ok = webgeo.MiddlewareResolve(r)
+	}
+	if ok {
+		ctx.SetUserValue(Key, res)
+	}
+	return res, ok
+}
+
+// FromContext returns the webgeo.Result stored by Middleware or Wrap. With
+// Fiber, pass c.Context().
+func FromContext(ctx *fasthttp.RequestCtx) (webgeo.Result, bool) {
+	res, ok := ctx.UserValue(Key).(webgeo.Result)
+	return res, ok
+}
+
+// ClientIP is the client IP webgeo uses for the request, see
+// webgeo.ClientIPFunc and webgeo.TrustedProxies. Pass nil for the package
+// settings.
+func ClientIP(g *webgeo.Geolocator, ctx *fasthttp.RequestCtx) string {
+	if g != nil {
+		return g.ClientIP(Request(ctx))
+	}
+	return webgeo.ClientIPFunc(Request(ctx))
+}
+
+// BrowserLangs parses the Accept-Language header of the request, see
+// webgeo.BrowserLangs
+func BrowserLangs(ctx *fasthttp.RequestCtx) []language.Tag {
+	h := http.Header{}
+	if v := ctx.Request.Header.Peek("Accept-Language"); len(v) > 0 {
+		h.Set("Accept-Language", string(v))
+	}
+	return webgeo.BrowserLangs(&http.Request{Header: h})
+}
+
+// Request is the net/http view of ctx webgeo works with: method, URL, peer
+// address and headers, without the body. The values are copied, fasthttp
+// reuses its buffers once the handler returns.
+func Request(ctx *fasthttp.RequestCtx) *http.Request {
+	r := &http.Request{
+		Method:     string(ctx.Method()),
+		RequestURI: string(ctx.RequestURI()),
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Host:       string(ctx.Host()),
+		RemoteAddr: ctx.RemoteAddr().String(),
+	}
+	if u, err := url.ParseRequestURI(r.RequestURI); err == nil {
+		r.URL = u
+	} else {
+		r.URL = &url.URL{Path: string(ctx.Path())}
+	}
+	ctx.Request.Header.VisitAll(func(k, v []byte) {
+		r.Header.Add(string(k), string(v))
+	})
+	if ctx.IsTLS() {
+		r.TLS = &tls.ConnectionState{}
+	}
+	return r.WithContext(ctx)
+}
@@ -0,0 +1,24 @@
+package webgeo
+
+import "strings"
+
+// NeutralFlag is returned by FlagEmoji for unknown locations
+const NeutralFlag = "\U0001F3F3\uFE0F" // white flag
+
+// FlagEmoji returns the flag of the country with the ISO code cc as a pair of
+// regional indicator symbols, e.g. "PL" gives 🇵🇱. Returns NeutralFlag for
+// "ZZ" and anything that isn't a 2 letter code.
+func FlagEmoji(cc string) string {
+	cc = strings.ToUpper(cc)
+	if len(cc) != 2 || cc == "ZZ" {
+		return NeutralFlag
+	}
+	flag := make([]rune, 0, 2)
+	for _, c := range cc {
+		if c < 'A' || c > 'Z' {
+			return NeutralFlag
+		}
+		flag = append(flag, '\U0001F1E6'+c-'A')
+	}
+	return string(flag)
+}
@@ -0,0 +1,26 @@
+package webgeo
+
+// ContinentPrefix marks the continent keys of the maps resolved by
+// ForCountry, so they can't collide with country codes: "continent:AF" is
+// Africa, "AF" Afghanistan.
+const ContinentPrefix = "continent:"
+
+// ForCountry resolves a per-market setting (price list, support phone,
+// ...) with inheritance: the entry for the country code cc, then the one for
+// ContinentPrefix and its continent code, then def. An empty continent is
+// taken from the embedded country table.
+//
+//	phones := map[string]string{"DE": "+49 30 555 0100", "continent:EU": "+44 20 555 0100"}
+//	phone := webgeo.ForCountry(phones, res.Country, "", "+1 800 555 0100")
+func ForCountry[T any](m map[string]T, cc, continent string, def T) T {
+	if v, pres := m[cc]; pres && cc != "" {
+		return v
+	}
+	if continent == "" {
+		continent = continentOf(cc)
+	}
+	if v, pres := m[ContinentPrefix+continent]; pres && continent != "" {
+		return v
+	}
+	return def
+}
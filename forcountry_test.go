@@ -0,0 +1,31 @@
+package webgeo
+
+import "testing"
+
+func TestForCountry(t *testing.T) {
+	m := map[string]string{
+		"NA":           "namibia",
+		"SA":           "saudi",
+		"continent:NA": "north-america",
+		"continent:EU": "europe",
+		"":             "unused",
+	}
+	tests := []struct {
+		cc, continent, want string
+	}{
+		{"NA", "", "namibia"},
+		{"US", "", "north-america"},
+		{"CA", "NA", "north-america"},
+		{"SA", "", "saudi"},
+		{"BR", "", "default"}, // "SA" is Saudi Arabia, not South America
+		{"AF", "", "default"}, // "AF" is no continent key
+		{"DE", "", "europe"},
+		{"", "", "default"},
+		{"XX", "", "default"},
+	}
+	for _, tt := range tests {
+		if got := ForCountry(m, tt.cc, tt.continent, "default"); got != tt.want {
+			t.Errorf("ForCountry(%q, %q) = %q, want %q", tt.cc, tt.continent, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package webgeo
+
+import "sort"
+
+// BlockedServices maps a third-party service name to the country codes or
+// the continents, after ContinentPrefix, where it is unreachable.
+// Templates use it to swap providers instead of letting the page hang on a
+// blocked script.
+var BlockedServices = map[string][]string{
+	"google-analytics":   {"CN"},
+	"google-fonts":       {"CN"},
+	"google-tag-manager": {"CN"},
+	"youtube":            {"CN"},
+	"facebook":           {"CN"},
+	"twitter":            {"CN"},
+}
+
+// Reachable reports whether the visitor can load service. Unknown services
+// are assumed reachable.
+func Reachable(res Result, service string) bool {
+	continent := ContinentPrefix + res.ContinentCode()
+	for _, code := range BlockedServices[service] {
+		if code == res.Country || code == continent {
+			return false
+		}
+	}
+	return true
+}
+
+// UnreachableServices lists the services from BlockedServices the visitor
+// can't load, sorted by name
+func UnreachableServices(res Result) []string {
+	services := []string{}
+	for s := range BlockedServices {
+		if !Reachable(res, s) {
+			services = append(services, s)
+		}
+	}
+	sort.Strings(services)
+	return services
+}
@@ -0,0 +1,29 @@
+package webgeo
+
+import "testing"
+
+func TestReachable(t *testing.T) {
+	saved := BlockedServices
+	defer func() { BlockedServices = saved }()
+	BlockedServices = map[string][]string{
+		"maps":  {"AF", "continent:SA"},
+		"video": {"continent:NA"},
+	}
+	tests := []struct {
+		country, service string
+		want             bool
+	}{
+		{"AF", "maps", false},
+		{"NG", "maps", true}, // "AF" is Afghanistan, not Africa
+		{"BR", "maps", false},
+		{"SA", "maps", true}, // Saudi Arabia is in Asia
+		{"US", "video", false},
+		{"NA", "video", true}, // Namibia is in Africa
+		{"US", "unknown", true},
+	}
+	for _, tt := range tests {
+		if got := Reachable(Result{Country: tt.country}, tt.service); got != tt.want {
+			t.Errorf("Reachable(%s, %s) = %v, want %v", tt.country, tt.service, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package webgeo
+
+import (
+	"log"
+	"net/http"
+	"net/netip"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/text/language"
+)
+
+// Geolocator owns a database, its lookup cache and the settings to find the
+// client IP. Settings not given as an Option fall back to the package
+// variables (DBPath, ASNDBPath, DBInMemory, TrustedProxies, ClientIPFunc,
+// FallbackProvider), so the package level functions use a Geolocator without
+// options.
+type Geolocator struct {
+	dbPath       string
+	asnDBPath    string
+	inMemory     *bool
+	byPrefix     *bool
+	proxies      []netip.Prefix
+	clientIP     func(r *http.Request) string
+	cacheSize    int
+	cacheBytes   int
+	cacheTTL     time.Duration
+	negativeTTL  time.Duration
+	customCache  Cache
+	logger       *log.Logger
+	precedence   Precedence
+	maxLangs     int
+	fallbackLang language.Tag
+	chains       bool
+	langCookie   string
+	langParam    string
+	localeCookie string
+	localeKey    []byte
+	contentLang  bool
+	serverTiming bool
+	jsonpParam   string
+	corsOrigins  []string
+	fallback     Fallback
+
+	cityDB *sharedDB
+	asnDB  *sharedDB
+	cache  Cache
+	counts *cacheCounts
+	// database swaps the cache was flushed for, shared by the copies
+	// sharing the cache
+	swapsSeen *uint64
+	// lookups in progress, by IP
+	lookups *singleflight.Group
+}
+
+// Option configures a Geolocator, see New and Geolocator.With
+type Option func(g *Geolocator)
+
+var defaultGeolocator = New()
+
+// New returns a Geolocator with its own database readers and cache
+func New(opts ...Option) *Geolocator {
+	g := &Geolocator{}
+	for _, opt := range opts {
+		opt(g)
+	}
+	g.cityDB, g.asnDB = newSharedDB(g.negativeLifetime), newSharedDB(g.negativeLifetime)
+	g.resetCache()
+	return g
+}
+
+// With returns a copy of g with opts applied on top of its settings, e.g. to
+// trust other proxies for some routes. The copy shares the database readers
+// and the cache of g unless opts change the databases or the cache settings.
+func (g *Geolocator) With(opts ...Option) *Geolocator {
+	c := *g
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.cityPath() != g.cityPath() || c.asnPath() != g.asnPath() || c.dbInMemory() != g.dbInMemory() {
+		c.cityDB, c.asnDB = newSharedDB(c.negativeLifetime), newSharedDB(c.negativeLifetime)
+		c.resetCache()
+	} else if c.cacheSize != g.cacheSize || c.cacheBytes != g.cacheBytes || c.cacheTTL != g.cacheTTL || c.negativeTTL != g.negativeTTL || c.cacheByPrefix() != g.cacheByPrefix() || c.customCache != g.customCache {
+		c.resetCache()
+	}
+	return &c
+}
+
+// Give g a new empty cache
+func (g *Geolocator) resetCache() {
+	g.cache, g.counts, g.lookups = g.newCache(), &cacheCounts{}, &singleflight.Group{}
+	swaps := g.dbSwaps()
+	g.swapsSeen = &swaps
+}
+
+func (g *Geolocator) dbSwaps() uint64 {
+	return g.cityDB.swapCount() + g.asnDB.swapCount()
+}
+
+// Cached lookups are stale once a new database is installed, e.g. by a
+// changed DBPath or a file replaced before CloseDB. The cache is flushed
+// by the first use after that rather than by the swap, so copies made by
+// With don't have to be tracked by the database readers they share.
+func (g *Geolocator) flushOnSwap() {
+	swaps := g.dbSwaps()
+	if seen := atomic.LoadUint64(g.swapsSeen); seen != swaps && atomic.CompareAndSwapUint64(g.swapsSeen, seen, swaps) {
+		g.FlushCache()
+	}
+}
+
+// WithDBPath sets the location of the City database, see DBPath
+func WithDBPath(path string) Option {
+	return func(g *Geolocator) { g.dbPath = path }
+}
+
+// WithASNDBPath sets the location of the ASN database, see ASNDBPath
+func WithASNDBPath(path string) Option {
+	return func(g *Geolocator) { g.asnDBPath = path }
+}
+
+// WithDBInMemory reads the databases into memory, see DBInMemory
+func WithDBInMemory(inMemory bool) Option {
+	return func(g *Geolocator) { g.inMemory = &inMemory }
+}
+
+// WithCacheSize bounds the lookup cache to n entries instead of CacheSize,
+// a negative n means unbounded
+func WithCacheSize(n int) Option {
+	return func(g *Geolocator) { g.cacheSize = n }
+}
+
+// WithCacheBytes caps the memory of the lookup cache to about n bytes
+// instead of CacheBytes, a negative n means no cap
+func WithCacheBytes(n int) Option {
+	return func(g *Geolocator) { g.cacheBytes = n }
+}
+
+// WithCacheTTL expires cached lookups after d instead of CacheTTL, a negative
+// d means never
+func WithCacheTTL(d time.Duration) Option {
+	return func(g *Geolocator) { g.cacheTTL = d }
+}
+
+// WithNegativeCacheTTL expires failed lookups after d instead of
+// NegativeCacheTTL, a negative d means they are not cached
+func WithNegativeCacheTTL(d time.Duration) Option {
+	return func(g *Geolocator) { g.negativeTTL = d }
+}
+
+// WithCacheByPrefix caches lookups by network, see CacheByPrefix
+func WithCacheByPrefix(byPrefix bool) Option {
+	return func(g *Geolocator) { g.byPrefix = &byPrefix }
+}
+
+// WithCache keeps lookups in c instead of the in-memory LRU, e.g. to share
+// them between processes. WithCacheSize and WithCacheTTL don't apply to c.
+func WithCache(c Cache) Option {
+	return func(g *Geolocator) { g.customCache = c }
+}
+
+// WithoutCache looks up every IP in the database, e.g. for batch jobs over
+// billions of distinct IPs where a cache only costs memory and time.
+// ResolveCached then never finds a location.
+func WithoutCache() Option {
+	return WithCache(noCache{})
+}
+
+// WithTrustedProxies sets the proxies whose X-Forwarded-For entries are
+// believed, see TrustedProxies. Ignored when WithClientIPFunc is given.
+func WithTrustedProxies(proxies ...netip.Prefix) Option {
+	return func(g *Geolocator) { g.proxies = append([]netip.Prefix{}, proxies...) }
+}
+
+// WithClientIPFunc sets how the client IP is extracted, see ClientIPFunc
+func WithClientIPFunc(f func(r *http.Request) string) Option {
+	return func(g *Geolocator) { g.clientIP = f }
+}
+
+// WithLogger sets the logger for database downloads, the standard logger by
+// default
+func WithLogger(l *log.Logger) Option {
+	return func(g *Geolocator) { g.logger = l }
+}
+
+// WithPrecedence sets how browser and country languages are combined
+func WithPrecedence(p Precedence) Option {
+	return func(g *Geolocator) { g.precedence = p }
+}
+
+// WithMaxLangs caps the number of languages returned, 0 means no limit
+func WithMaxLangs(n int) Option {
+	return func(g *Geolocator) { g.maxLangs = n }
+}
+
+// WithFallbackLang makes sure the returned languages include tag, e.g. the
+// language every page is translated to. It is added last when missing, even
+// when capped by WithMaxLangs, unless WithMaxLangs(1) leaves room for the
+// preferred language only.
+func WithFallbackLang(tag language.Tag) Option {
+	return func(g *Geolocator) { g.fallbackLang = tag }
+}
+
+// WithFallbackChains replaces each returned language by its FallbackChain,
+// e.g. de-AT, fr gives de-AT, de, fr, en
+func WithFallbackChains() Option {
+	return func(g *Geolocator) { g.chains = true }
+}
+
+func (g *Geolocator) newCache() Cache {
+	if g.customCache != nil {
+		return g.customCache
+	}
+	return newLookupCache(g.maxCacheEntries, g.maxCacheBytes, g.entryLifetime)
+}
+
+func (g *Geolocator) maxCacheEntries() int {
+	if g.cacheSize < 0 {
+		return 0
+	}
+	if g.cacheSize > 0 {
+		return g.cacheSize
+	}
+	return CacheSize
+}
+
+func (g *Geolocator) maxCacheBytes() int {
+	if g.cacheBytes < 0 {
+		return 0
+	}
+	if g.cacheBytes > 0 {
+		return g.cacheBytes
+	}
+	return CacheBytes
+}
+
+func (g *Geolocator) cacheLifetime() time.Duration {
+	if g.cacheTTL < 0 {
+		return 0
+	}
+	if g.cacheTTL > 0 {
+		return g.cacheTTL
+	}
+	return CacheTTL
+}
+
+func (g *Geolocator) cacheByPrefix() bool {
+	if g.byPrefix != nil {
+		return *g.byPrefix
+	}
+	return CacheByPrefix
+}
+
+// the key of ipS in the cache, its network with cacheByPrefix
+func (g *Geolocator) cacheKey(ipS string) string {
+	if !g.cacheByPrefix() {
+		return ipS
+	}
+	addr, err := netip.ParseAddr(ipS)
+	if err != nil {
+		return ipS
+	}
+	addr = addr.Unmap()
+	bits := 48
+	if addr.Is4() {
+		bits = 24
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return ipS
+	}
+	return prefix.String()
+}
+
+func (g *Geolocator) negativeLifetime() time.Duration {
+	if g.negativeTTL != 0 {
+		return g.negativeTTL
+	}
+	return NegativeCacheTTL
+}
+
+// how long e stays in the in-memory cache
+func (g *Geolocator) entryLifetime(e CacheEntry) time.Duration {
+	return negativeLifetime(e, g.cacheLifetime(), g.negativeLifetime())
+}
+
+func (g *Geolocator) cityPath() string {
+	if g.dbPath != "" {
+		return g.dbPath
+	}
+	return DBPath
+}
+
+func (g *Geolocator) asnPath() string {
+	if g.asnDBPath != "" {
+		return g.asnDBPath
+	}
+	return ASNDBPath
+}
+
+func (g *Geolocator) dbInMemory() bool {
+	if g.inMemory != nil {
+		return *g.inMemory
+	}
+	return DBInMemory
+}
+
+func (g *Geolocator) logf(format string, v ...interface{}) {
+	if g.logger != nil {
+		g.logger.Printf(format, v...)
+		return
+	}
+	log.Printf(format, v...)
+}
+
+// ClientIP returns the client IP of the request, "" when there is none
+func (g *Geolocator) ClientIP(r *http.Request) string {
+	if g.clientIP != nil {
+		return g.clientIP(r)
+	}
+	if g.proxies != nil {
+		return rightmostTrustedIP(r, g.proxies)
+	}
+	return ClientIPFunc(r)
+}
+
+// Close releases the database readers. The next lookup opens them again.
+func (g *Geolocator) Close() error {
+	err := g.cityDB.close()
+	if err2 := g.asnDB.close(); err == nil {
+		err = err2
+	}
+	return err
+}
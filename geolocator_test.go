@@ -0,0 +1,33 @@
+package webgeo
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithFlushOnSwap(t *testing.T) {
+	g := New(WithDBPath(brokenDB(t)))
+	copies := []*Geolocator{g, g.With(WithCacheTTL(time.Hour)), g.With(WithCacheSize(10))}
+	for _, c := range copies {
+		c.cache.Set("192.0.2.1", c.withLifetime(newGeoEntry(&GeoRecord{Ip: "192.0.2.1", Cc: "DE"}, nil)))
+		c.flushOnSwap()
+		if n := c.cache.Len(); n != 1 {
+			t.Fatalf("%d cached before the swap, want 1", n)
+		}
+	}
+	atomic.AddUint64(&g.cityDB.swaps, 1)
+	for i, c := range copies {
+		c.flushOnSwap()
+		if n := c.cache.Len(); n != 0 {
+			t.Errorf("copy %d: %d cached after the swap, want 0", i, n)
+		}
+	}
+	// a copy made after the swap starts clean and isn't flushed for it
+	c := g.With(WithCacheSize(20))
+	c.cache.Set("192.0.2.1", c.withLifetime(newGeoEntry(&GeoRecord{Ip: "192.0.2.1", Cc: "DE"}, nil)))
+	c.flushOnSwap()
+	if n := c.cache.Len(); n != 1 {
+		t.Errorf("%d cached in a copy made after the swap, want 1", n)
+	}
+}
@@ -0,0 +1,46 @@
+// Package ginadapter plugs webgeo into Gin:
+//
+//	r := gin.New()
+//	r.Use(ginadapter.Middleware(nil))
+//	r.GET("/", func(c *gin.Context) {
+//		res, _ := ginadapter.FromContext(c)
+//		c.String(200, res.Country)
+//	})
+package ginadapter
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/seckiss/webgeo"
+)
+
+// Key under which Middleware stores the webgeo.Result with c.Set
+const Key = "geo"
+
+// Middleware resolves each request with g, or the package settings when g is
+// nil, and stores the webgeo.Result under Key and in the request context,
+// see webgeo.ResultFromContext. The client IP is found by webgeo's
+// TrustedProxies, not Gin's. Requests with webgeo.SkipMethods only get a
+// Result when the location is already cached.
+func Middleware(g *webgeo.Geolocator) gin.HandlerFunc {
+	resolve := webgeo.MiddlewareResolve
+	if g != nil {
+		resolve = g.MiddlewareResolve
+	}
+	return func(c *gin.Context) {
+		if res, ok := resolve(c.Request); ok {
+			c.Set(Key, res)
+			c.Request = c.Request.WithContext(webgeo.NewContext(c.Request.Context(), res))
+		}
+		c.Next()
+	}
+}
+
+// FromContext returns the webgeo.Result stored by Middleware
+func FromContext(c *gin.Context) (webgeo.Result, bool) {
+	v, ok := c.Get(Key)
+	if !ok {
+		return webgeo.Result{}, false
+	}
+	res, ok := v.(webgeo.Result)
+	return res, ok
+}
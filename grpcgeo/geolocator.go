@@ -0,0 +1,38 @@
+package grpcgeo
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+
+	"github.com/seckiss/webgeo"
+)
+
+// the part of *webgeo.Geolocator the server uses
+type geolocator interface {
+	LookupResult(ctx context.Context, addr netip.Addr) (webgeo.Result, error)
+	LookupBatch(ips []netip.Addr) []webgeo.Result
+	ResolveCtx(ctx context.Context, r *http.Request) (webgeo.Result, error)
+}
+
+func newGeolocator(g *webgeo.Geolocator) geolocator {
+	if g == nil {
+		return packageGeolocator{}
+	}
+	return g
+}
+
+// the package functions as a geolocator
+type packageGeolocator struct{}
+
+func (packageGeolocator) LookupResult(ctx context.Context, addr netip.Addr) (webgeo.Result, error) {
+	return webgeo.LookupResult(ctx, addr)
+}
+
+func (packageGeolocator) LookupBatch(ips []netip.Addr) []webgeo.Result {
+	return webgeo.LookupBatch(ips)
+}
+
+func (packageGeolocator) ResolveCtx(ctx context.Context, r *http.Request) (webgeo.Result, error) {
+	return webgeo.ResolveCtx(ctx, r)
+}
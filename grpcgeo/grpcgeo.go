@@ -0,0 +1,196 @@
+// Package grpcgeo serves webgeo over gRPC as webgeo.v1.GeoService, see
+// proto/webgeo/v1/geo.proto, for services that are not written in Go:
+//
+//	s := grpc.NewServer()
+//	webgeov1.RegisterGeoServiceServer(s, grpcgeo.NewServer(nil))
+package grpcgeo
+
+//go:generate protoc -I ../proto --go_out=.. --go_opt=module=github.com/seckiss/webgeo --go-grpc_out=.. --go-grpc_opt=module=github.com/seckiss/webgeo webgeo/v1/geo.proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+
+	"github.com/seckiss/webgeo"
+	"github.com/seckiss/webgeo/grpcgeo/webgeov1"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MaxBatch is the most IPs a BatchLookup call may carry
+var MaxBatch = 10000
+
+// Server implements webgeov1.GeoServiceServer. Embed it to add or override
+// methods.
+type Server struct {
+	webgeov1.UnimplementedGeoServiceServer
+	g geolocator
+}
+
+// NewServer serves the lookups of g, or of the package settings when g is nil
+func NewServer(g *webgeo.Geolocator) *Server {
+	return &Server{g: newGeolocator(g)}
+}
+
+func (s *Server) Lookup(ctx context.Context, req *webgeov1.LookupRequest) (*webgeov1.LookupResponse, error) {
+	addr, err := parseIP(req.Ip)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	res, err := s.g.LookupResult(ctx, addr)
+	if err != nil {
+		return nil, lookupStatus(err)
+	}
+	return &webgeov1.LookupResponse{Result: ResultToProto(res)}, nil
+}
+
+func (s *Server) BatchLookup(ctx context.Context, req *webgeov1.BatchLookupRequest) (*webgeov1.BatchLookupResponse, error) {
+	if len(req.Ips) > MaxBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "Too many IPs: %d, at most %d", len(req.Ips), MaxBatch)
+	}
+	addrs := make([]netip.Addr, len(req.Ips))
+	for i, ip := range req.Ips {
+		// invalid addresses stay zero and get ZZ
+		addrs[i], _ = parseIP(ip)
+	}
+	resp := &webgeov1.BatchLookupResponse{Results: make([]*webgeov1.Result, len(addrs))}
+	for i, res := range s.g.LookupBatch(addrs) {
+		resp.Results[i] = ResultToProto(res)
+	}
+	return resp, nil
+}
+
+func (s *Server) Negotiate(ctx context.Context, req *webgeov1.NegotiateRequest) (*webgeov1.NegotiateResponse, error) {
+	if req.Ip != "" {
+		if _, err := parseIP(req.Ip); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	supported := []language.Tag{}
+	for _, l := range req.Supported {
+		t, err := language.Parse(l)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid locale %q", l)
+		}
+		supported = append(supported, t)
+	}
+	r := Request(ctx, req.Ip, req.AcceptLanguage)
+	if req.Ip == "" {
+		var ok bool
+		if r, ok = IncomingRequest(ctx); !ok {
+			return nil, status.Error(codes.InvalidArgument, webgeo.ErrNoClientIP.Error())
+		}
+		if req.AcceptLanguage != "" {
+			r.Header.Set("Accept-Language", req.AcceptLanguage)
+		}
+	}
+	res, err := s.g.ResolveCtx(ctx, r)
+	if err != nil {
+		return nil, lookupStatus(err)
+	}
+	resp := &webgeov1.NegotiateResponse{Result: ResultToProto(res)}
+	if len(supported) > 0 {
+		resp.BestLocale = bestLocale(res, supported).String()
+	}
+	return resp, nil
+}
+
+// The supported locale matching the languages of res best, as
+// webgeo.BestLocale gives for the request of res
+func bestLocale(res webgeo.Result, supported []language.Tag) language.Tag {
+	tags := []language.Tag{}
+	for _, l := range res.Langs {
+		if t, err := language.Parse(l); err == nil {
+			tags = append(tags, t)
+		}
+	}
+	_, i, _ := language.NewMatcher(supported).Match(tags...)
+	return supported[i]
+}
+
+// Request is the http.Request webgeo resolves for a client with ip and the
+// Accept-Language header acceptLang
+func Request(ctx context.Context, ip, acceptLang string) *http.Request {
+	r := &http.Request{Method: http.MethodGet, RemoteAddr: ip, Header: http.Header{}}
+	if acceptLang != "" {
+		r.Header.Set("Accept-Language", acceptLang)
+	}
+	return r.WithContext(ctx)
+}
+
+func parseIP(ip string) (netip.Addr, error) {
+	if addr, err := netip.ParseAddrPort(ip); err == nil {
+		return addr.Addr().Unmap(), nil
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("Invalid IP %q", ip)
+	}
+	return addr.Unmap(), nil
+}
+
+func lookupStatus(err error) error {
+	var dbErr *webgeo.DBError
+	if errors.As(err, &dbErr) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return status.FromContextError(err).Err()
+}
+
+// ResultToProto converts res for the wire
+func ResultToProto(res webgeo.Result) *webgeov1.Result {
+	p := &webgeov1.Result{
+		Country:      res.Country,
+		Langs:        res.Langs,
+		Sources:      make(map[string]string, len(res.Sources)),
+		Geo:          GeoToProto(res.Geo),
+		Conflict:     res.Conflict,
+		Currency:     res.Currency,
+		CurrencyName: res.CurrencyName,
+		CallingCode:  res.CallingCode,
+	}
+	for l, src := range res.Sources {
+		p.Sources[l] = string(src)
+	}
+	return p
+}
+
+// GeoToProto converts geo for the wire, nil stays nil
+func GeoToProto(geo *webgeo.GeoRecord) *webgeov1.GeoRecord {
+	if geo == nil {
+		return nil
+	}
+	p := &webgeov1.GeoRecord{
+		Ip:                           geo.Ip,
+		Cc:                           geo.Cc,
+		Country:                      geo.Country,
+		City:                         geo.City,
+		TimeZone:                     geo.TimeZone,
+		Latitude:                     geo.Latitude,
+		Longitude:                    geo.Longitude,
+		AccuracyRadius:               uint32(geo.AccuracyRadius),
+		ContinentCode:                geo.ContinentCode,
+		Continent:                    geo.Continent,
+		RegisteredCountry:            geo.RegisteredCountry,
+		RepresentedCountry:           geo.RepresentedCountry,
+		IsInEuropeanUnion:            geo.IsInEuropeanUnion,
+		IsAnonymousProxy:             geo.IsAnonymousProxy,
+		IsSatelliteProvider:          geo.IsSatelliteProvider,
+		AutonomousSystemNumber:       uint32(geo.AutonomousSystemNumber),
+		AutonomousSystemOrganization: geo.AutonomousSystemOrganization,
+		MostSpecificSubdivision:      subdivisionToProto(geo.MostSpecificSubdivision),
+		Warnings:                     geo.Warnings,
+	}
+	for _, s := range geo.Subdivisions {
+		p.Subdivisions = append(p.Subdivisions, subdivisionToProto(s))
+	}
+	return p
+}
+
+func subdivisionToProto(s webgeo.Subdivision) *webgeov1.Subdivision {
+	return &webgeov1.Subdivision{IsoCode: s.IsoCode, Name: s.Name}
+}
@@ -0,0 +1,51 @@
+package grpcgeo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seckiss/webgeo"
+	"github.com/seckiss/webgeo/grpcgeo/webgeov1"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func brokenServer(t *testing.T) *Server {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "broken.mmdb")
+	if err := os.WriteFile(path, []byte("not a database"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return NewServer(webgeo.New(webgeo.WithDBPath(path), webgeo.WithoutCache()))
+}
+
+func TestBrokenDBUnavailable(t *testing.T) {
+	s := brokenServer(t)
+	if _, err := s.Lookup(context.Background(), &webgeov1.LookupRequest{Ip: "192.0.2.1"}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Lookup: %v, want Unavailable", err)
+	}
+	req := &webgeov1.NegotiateRequest{Ip: "192.0.2.1", AcceptLanguage: "de", Supported: []string{"en", "de"}}
+	if _, err := s.Negotiate(context.Background(), req); status.Code(err) != codes.Unavailable {
+		t.Errorf("Negotiate: %v, want Unavailable", err)
+	}
+}
+
+func TestBestLocale(t *testing.T) {
+	supported := []language.Tag{language.English, language.German, language.French}
+	tests := []struct {
+		langs []string
+		want  language.Tag
+	}{
+		{[]string{"de-AT", "en"}, language.German},
+		{[]string{"it"}, language.English},
+		{nil, language.English},
+	}
+	for _, tt := range tests {
+		if got := bestLocale(webgeo.Result{Langs: tt.langs}, supported); got != tt.want {
+			t.Errorf("bestLocale(%v) = %v, want %v", tt.langs, got, tt.want)
+		}
+	}
+}
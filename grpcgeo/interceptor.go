@@ -0,0 +1,79 @@
+package grpcgeo
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/seckiss/webgeo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Metadata keys the interceptors read, e.g. set by a grpc-gateway or Envoy
+// in front of the server. X-Forwarded-For is only believed as far as
+// webgeo.TrustedProxies allow.
+const (
+	AcceptLanguageKey = "accept-language"
+	ForwardedForKey   = "x-forwarded-for"
+)
+
+// UnaryServerInterceptor is the gRPC equivalent of webgeo.Middleware: it
+// resolves the peer of each call with g, or the package settings when g is
+// nil, and attaches the webgeo.Result to the context of the handler, see
+// webgeo.ResultFromContext. Calls whose deadline expires during the lookup
+// carry on without a Result.
+func UnaryServerInterceptor(g *webgeo.Geolocator) grpc.UnaryServerInterceptor {
+	gl := newGeolocator(g)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withResult(ctx, gl), req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streams
+func StreamServerInterceptor(g *webgeo.Geolocator) grpc.StreamServerInterceptor {
+	gl := newGeolocator(g)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ss, withResult(ss.Context(), gl)})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func withResult(ctx context.Context, g geolocator) context.Context {
+	r, ok := IncomingRequest(ctx)
+	if !ok {
+		return ctx
+	}
+	res, err := g.ResolveCtx(ctx, r)
+	if err != nil {
+		return ctx
+	}
+	return webgeo.NewContext(ctx, res)
+}
+
+// IncomingRequest is the http.Request webgeo resolves for the peer of a
+// server call, with the AcceptLanguageKey and ForwardedForKey metadata as
+// headers. ok is false when ctx has no peer.
+func IncomingRequest(ctx context.Context) (r *http.Request, ok bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return nil, false
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	r = Request(ctx, p.Addr.String(), "")
+	for _, v := range md.Get(AcceptLanguageKey) {
+		r.Header.Add("Accept-Language", v)
+	}
+	for _, v := range md.Get(ForwardedForKey) {
+		r.Header.Add("X-Forwarded-For", v)
+	}
+	return r, true
+}
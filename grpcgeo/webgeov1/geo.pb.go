@@ -0,0 +1,805 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: webgeo/v1/geo.proto
+
+package webgeov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LookupRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IPv4 or IPv6, may include a port
+	Ip            string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupRequest) Reset() {
+	*x = LookupRequest{}
+	mi := &file_webgeo_v1_geo_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupRequest) ProtoMessage() {}
+
+func (x *LookupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webgeo_v1_geo_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupRequest.ProtoReflect.Descriptor instead.
+func (*LookupRequest) Descriptor() ([]byte, []int) {
+	return file_webgeo_v1_geo_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LookupRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+type LookupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *Result                `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupResponse) Reset() {
+	*x = LookupResponse{}
+	mi := &file_webgeo_v1_geo_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupResponse) ProtoMessage() {}
+
+func (x *LookupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_webgeo_v1_geo_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupResponse.ProtoReflect.Descriptor instead.
+func (*LookupResponse) Descriptor() ([]byte, []int) {
+	return file_webgeo_v1_geo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LookupResponse) GetResult() *Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type BatchLookupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ips           []string               `protobuf:"bytes,1,rep,name=ips,proto3" json:"ips,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchLookupRequest) Reset() {
+	*x = BatchLookupRequest{}
+	mi := &file_webgeo_v1_geo_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchLookupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchLookupRequest) ProtoMessage() {}
+
+func (x *BatchLookupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webgeo_v1_geo_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchLookupRequest.ProtoReflect.Descriptor instead.
+func (*BatchLookupRequest) Descriptor() ([]byte, []int) {
+	return file_webgeo_v1_geo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchLookupRequest) GetIps() []string {
+	if x != nil {
+		return x.Ips
+	}
+	return nil
+}
+
+type BatchLookupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*Result              `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchLookupResponse) Reset() {
+	*x = BatchLookupResponse{}
+	mi := &file_webgeo_v1_geo_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchLookupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchLookupResponse) ProtoMessage() {}
+
+func (x *BatchLookupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_webgeo_v1_geo_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchLookupResponse.ProtoReflect.Descriptor instead.
+func (*BatchLookupResponse) Descriptor() ([]byte, []int) {
+	return file_webgeo_v1_geo_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchLookupResponse) GetResults() []*Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type NegotiateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the client IP; when empty, the peer of the call, behind the proxies in
+	// its x-forwarded-for metadata
+	Ip string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	// the Accept-Language header of the client, the accept-language metadata
+	// of the call when empty and ip is too
+	AcceptLanguage string `protobuf:"bytes,2,opt,name=accept_language,json=acceptLanguage,proto3" json:"accept_language,omitempty"`
+	// BCP 47 locales of the application, best_locale is only set when given
+	Supported     []string `protobuf:"bytes,3,rep,name=supported,proto3" json:"supported,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NegotiateRequest) Reset() {
+	*x = NegotiateRequest{}
+	mi := &file_webgeo_v1_geo_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NegotiateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NegotiateRequest) ProtoMessage() {}
+
+func (x *NegotiateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webgeo_v1_geo_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NegotiateRequest.ProtoReflect.Descriptor instead.
+func (*NegotiateRequest) Descriptor() ([]byte, []int) {
+	return file_webgeo_v1_geo_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *NegotiateRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *NegotiateRequest) GetAcceptLanguage() string {
+	if x != nil {
+		return x.AcceptLanguage
+	}
+	return ""
+}
+
+func (x *NegotiateRequest) GetSupported() []string {
+	if x != nil {
+		return x.Supported
+	}
+	return nil
+}
+
+type NegotiateResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Result *Result                `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// the best of the supported locales, see webgeo.BestLocale
+	BestLocale    string `protobuf:"bytes,2,opt,name=best_locale,json=bestLocale,proto3" json:"best_locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NegotiateResponse) Reset() {
+	*x = NegotiateResponse{}
+	mi := &file_webgeo_v1_geo_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NegotiateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NegotiateResponse) ProtoMessage() {}
+
+func (x *NegotiateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_webgeo_v1_geo_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NegotiateResponse.ProtoReflect.Descriptor instead.
+func (*NegotiateResponse) Descriptor() ([]byte, []int) {
+	return file_webgeo_v1_geo_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NegotiateResponse) GetResult() *Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *NegotiateResponse) GetBestLocale() string {
+	if x != nil {
+		return x.BestLocale
+	}
+	return ""
+}
+
+// Result mirrors webgeo.Result
+type Result struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ISO country code, "ZZ" when unknown
+	Country string `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	// BCP 47 languages, most preferred first
+	Langs []string `protobuf:"bytes,2,rep,name=langs,proto3" json:"langs,omitempty"`
+	// where each of langs comes from: browser, geo, override, fallback or stored
+	Sources map[string]string `protobuf:"bytes,3,rep,name=sources,proto3" json:"sources,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// unset when the location is unknown
+	Geo           *GeoRecord `protobuf:"bytes,4,opt,name=geo,proto3" json:"geo,omitempty"`
+	Conflict      bool       `protobuf:"varint,5,opt,name=conflict,proto3" json:"conflict,omitempty"`
+	Currency      string     `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	CurrencyName  string     `protobuf:"bytes,7,opt,name=currency_name,json=currencyName,proto3" json:"currency_name,omitempty"`
+	CallingCode   string     `protobuf:"bytes,8,opt,name=calling_code,json=callingCode,proto3" json:"calling_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Result) Reset() {
+	*x = Result{}
+	mi := &file_webgeo_v1_geo_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Result) ProtoMessage() {}
+
+func (x *Result) ProtoReflect() protoreflect.Message {
+	mi := &file_webgeo_v1_geo_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Result.ProtoReflect.Descriptor instead.
+func (*Result) Descriptor() ([]byte, []int) {
+	return file_webgeo_v1_geo_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Result) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Result) GetLangs() []string {
+	if x != nil {
+		return x.Langs
+	}
+	return nil
+}
+
+func (x *Result) GetSources() map[string]string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *Result) GetGeo() *GeoRecord {
+	if x != nil {
+		return x.Geo
+	}
+	return nil
+}
+
+func (x *Result) GetConflict() bool {
+	if x != nil {
+		return x.Conflict
+	}
+	return false
+}
+
+func (x *Result) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Result) GetCurrencyName() string {
+	if x != nil {
+		return x.CurrencyName
+	}
+	return ""
+}
+
+func (x *Result) GetCallingCode() string {
+	if x != nil {
+		return x.CallingCode
+	}
+	return ""
+}
+
+// GeoRecord mirrors webgeo.GeoRecord
+type GeoRecord struct {
+	state                        protoimpl.MessageState `protogen:"open.v1"`
+	Ip                           string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Cc                           string                 `protobuf:"bytes,2,opt,name=cc,proto3" json:"cc,omitempty"`
+	Country                      string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	City                         string                 `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	TimeZone                     string                 `protobuf:"bytes,5,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	Latitude                     float64                `protobuf:"fixed64,6,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude                    float64                `protobuf:"fixed64,7,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	AccuracyRadius               uint32                 `protobuf:"varint,8,opt,name=accuracy_radius,json=accuracyRadius,proto3" json:"accuracy_radius,omitempty"`
+	ContinentCode                string                 `protobuf:"bytes,9,opt,name=continent_code,json=continentCode,proto3" json:"continent_code,omitempty"`
+	Continent                    string                 `protobuf:"bytes,10,opt,name=continent,proto3" json:"continent,omitempty"`
+	RegisteredCountry            string                 `protobuf:"bytes,11,opt,name=registered_country,json=registeredCountry,proto3" json:"registered_country,omitempty"`
+	RepresentedCountry           string                 `protobuf:"bytes,12,opt,name=represented_country,json=representedCountry,proto3" json:"represented_country,omitempty"`
+	IsInEuropeanUnion            bool                   `protobuf:"varint,13,opt,name=is_in_european_union,json=isInEuropeanUnion,proto3" json:"is_in_european_union,omitempty"`
+	IsAnonymousProxy             bool                   `protobuf:"varint,14,opt,name=is_anonymous_proxy,json=isAnonymousProxy,proto3" json:"is_anonymous_proxy,omitempty"`
+	IsSatelliteProvider          bool                   `protobuf:"varint,15,opt,name=is_satellite_provider,json=isSatelliteProvider,proto3" json:"is_satellite_provider,omitempty"`
+	AutonomousSystemNumber       uint32                 `protobuf:"varint,16,opt,name=autonomous_system_number,json=autonomousSystemNumber,proto3" json:"autonomous_system_number,omitempty"`
+	AutonomousSystemOrganization string                 `protobuf:"bytes,17,opt,name=autonomous_system_organization,json=autonomousSystemOrganization,proto3" json:"autonomous_system_organization,omitempty"`
+	Subdivisions                 []*Subdivision         `protobuf:"bytes,18,rep,name=subdivisions,proto3" json:"subdivisions,omitempty"`
+	MostSpecificSubdivision      *Subdivision           `protobuf:"bytes,19,opt,name=most_specific_subdivision,json=mostSpecificSubdivision,proto3" json:"most_specific_subdivision,omitempty"`
+	Warnings                     []string               `protobuf:"bytes,20,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *GeoRecord) Reset() {
+	*x = GeoRecord{}
+	mi := &file_webgeo_v1_geo_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoRecord) ProtoMessage() {}
+
+func (x *GeoRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_webgeo_v1_geo_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoRecord.ProtoReflect.Descriptor instead.
+func (*GeoRecord) Descriptor() ([]byte, []int) {
+	return file_webgeo_v1_geo_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GeoRecord) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *GeoRecord) GetCc() string {
+	if x != nil {
+		return x.Cc
+	}
+	return ""
+}
+
+func (x *GeoRecord) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *GeoRecord) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *GeoRecord) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *GeoRecord) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GeoRecord) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *GeoRecord) GetAccuracyRadius() uint32 {
+	if x != nil {
+		return x.AccuracyRadius
+	}
+	return 0
+}
+
+func (x *GeoRecord) GetContinentCode() string {
+	if x != nil {
+		return x.ContinentCode
+	}
+	return ""
+}
+
+func (x *GeoRecord) GetContinent() string {
+	if x != nil {
+		return x.Continent
+	}
+	return ""
+}
+
+func (x *GeoRecord) GetRegisteredCountry() string {
+	if x != nil {
+		return x.RegisteredCountry
+	}
+	return ""
+}
+
+func (x *GeoRecord) GetRepresentedCountry() string {
+	if x != nil {
+		return x.RepresentedCountry
+	}
+	return ""
+}
+
+func (x *GeoRecord) GetIsInEuropeanUnion() bool {
+	if x != nil {
+		return x.IsInEuropeanUnion
+	}
+	return false
+}
+
+func (x *GeoRecord) GetIsAnonymousProxy() bool {
+	if x != nil {
+		return x.IsAnonymousProxy
+	}
+	return false
+}
+
+func (x *GeoRecord) GetIsSatelliteProvider() bool {
+	if x != nil {
+		return x.IsSatelliteProvider
+	}
+	return false
+}
+
+func (x *GeoRecord) GetAutonomousSystemNumber() uint32 {
+	if x != nil {
+		return x.AutonomousSystemNumber
+	}
+	return 0
+}
+
+func (x *GeoRecord) GetAutonomousSystemOrganization() string {
+	if x != nil {
+		return x.AutonomousSystemOrganization
+	}
+	return ""
+}
+
+func (x *GeoRecord) GetSubdivisions() []*Subdivision {
+	if x != nil {
+		return x.Subdivisions
+	}
+	return nil
+}
+
+func (x *GeoRecord) GetMostSpecificSubdivision() *Subdivision {
+	if x != nil {
+		return x.MostSpecificSubdivision
+	}
+	return nil
+}
+
+func (x *GeoRecord) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type Subdivision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsoCode       string                 `protobuf:"bytes,1,opt,name=iso_code,json=isoCode,proto3" json:"iso_code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Subdivision) Reset() {
+	*x = Subdivision{}
+	mi := &file_webgeo_v1_geo_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subdivision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subdivision) ProtoMessage() {}
+
+func (x *Subdivision) ProtoReflect() protoreflect.Message {
+	mi := &file_webgeo_v1_geo_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subdivision.ProtoReflect.Descriptor instead.
+func (*Subdivision) Descriptor() ([]byte, []int) {
+	return file_webgeo_v1_geo_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Subdivision) GetIsoCode() string {
+	if x != nil {
+		return x.IsoCode
+	}
+	return ""
+}
+
+func (x *Subdivision) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_webgeo_v1_geo_proto protoreflect.FileDescriptor
+
+const file_webgeo_v1_geo_proto_rawDesc = "" +
+	"\n" +
+	"\x13webgeo/v1/geo.proto\x12\twebgeo.v1\"\x1f\n" +
+	"\rLookupRequest\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\";\n" +
+	"\x0eLookupResponse\x12)\n" +
+	"\x06result\x18\x01 \x01(\v2\x11.webgeo.v1.ResultR\x06result\"&\n" +
+	"\x12BatchLookupRequest\x12\x10\n" +
+	"\x03ips\x18\x01 \x03(\tR\x03ips\"B\n" +
+	"\x13BatchLookupResponse\x12+\n" +
+	"\aresults\x18\x01 \x03(\v2\x11.webgeo.v1.ResultR\aresults\"i\n" +
+	"\x10NegotiateRequest\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\x12'\n" +
+	"\x0faccept_language\x18\x02 \x01(\tR\x0eacceptLanguage\x12\x1c\n" +
+	"\tsupported\x18\x03 \x03(\tR\tsupported\"_\n" +
+	"\x11NegotiateResponse\x12)\n" +
+	"\x06result\x18\x01 \x01(\v2\x11.webgeo.v1.ResultR\x06result\x12\x1f\n" +
+	"\vbest_locale\x18\x02 \x01(\tR\n" +
+	"bestLocale\"\xd6\x02\n" +
+	"\x06Result\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12\x14\n" +
+	"\x05langs\x18\x02 \x03(\tR\x05langs\x128\n" +
+	"\asources\x18\x03 \x03(\v2\x1e.webgeo.v1.Result.SourcesEntryR\asources\x12&\n" +
+	"\x03geo\x18\x04 \x01(\v2\x14.webgeo.v1.GeoRecordR\x03geo\x12\x1a\n" +
+	"\bconflict\x18\x05 \x01(\bR\bconflict\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12#\n" +
+	"\rcurrency_name\x18\a \x01(\tR\fcurrencyName\x12!\n" +
+	"\fcalling_code\x18\b \x01(\tR\vcallingCode\x1a:\n" +
+	"\fSourcesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbd\x06\n" +
+	"\tGeoRecord\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\x12\x0e\n" +
+	"\x02cc\x18\x02 \x01(\tR\x02cc\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x12\n" +
+	"\x04city\x18\x04 \x01(\tR\x04city\x12\x1b\n" +
+	"\ttime_zone\x18\x05 \x01(\tR\btimeZone\x12\x1a\n" +
+	"\blatitude\x18\x06 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\a \x01(\x01R\tlongitude\x12'\n" +
+	"\x0faccuracy_radius\x18\b \x01(\rR\x0eaccuracyRadius\x12%\n" +
+	"\x0econtinent_code\x18\t \x01(\tR\rcontinentCode\x12\x1c\n" +
+	"\tcontinent\x18\n" +
+	" \x01(\tR\tcontinent\x12-\n" +
+	"\x12registered_country\x18\v \x01(\tR\x11registeredCountry\x12/\n" +
+	"\x13represented_country\x18\f \x01(\tR\x12representedCountry\x12/\n" +
+	"\x14is_in_european_union\x18\r \x01(\bR\x11isInEuropeanUnion\x12,\n" +
+	"\x12is_anonymous_proxy\x18\x0e \x01(\bR\x10isAnonymousProxy\x122\n" +
+	"\x15is_satellite_provider\x18\x0f \x01(\bR\x13isSatelliteProvider\x128\n" +
+	"\x18autonomous_system_number\x18\x10 \x01(\rR\x16autonomousSystemNumber\x12D\n" +
+	"\x1eautonomous_system_organization\x18\x11 \x01(\tR\x1cautonomousSystemOrganization\x12:\n" +
+	"\fsubdivisions\x18\x12 \x03(\v2\x16.webgeo.v1.SubdivisionR\fsubdivisions\x12R\n" +
+	"\x19most_specific_subdivision\x18\x13 \x01(\v2\x16.webgeo.v1.SubdivisionR\x17mostSpecificSubdivision\x12\x1a\n" +
+	"\bwarnings\x18\x14 \x03(\tR\bwarnings\"<\n" +
+	"\vSubdivision\x12\x19\n" +
+	"\biso_code\x18\x01 \x01(\tR\aisoCode\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name2\xe1\x01\n" +
+	"\n" +
+	"GeoService\x12=\n" +
+	"\x06Lookup\x12\x18.webgeo.v1.LookupRequest\x1a\x19.webgeo.v1.LookupResponse\x12L\n" +
+	"\vBatchLookup\x12\x1d.webgeo.v1.BatchLookupRequest\x1a\x1e.webgeo.v1.BatchLookupResponse\x12F\n" +
+	"\tNegotiate\x12\x1b.webgeo.v1.NegotiateRequest\x1a\x1c.webgeo.v1.NegotiateResponseB5Z3github.com/seckiss/webgeo/grpcgeo/webgeov1;webgeov1b\x06proto3"
+
+var (
+	file_webgeo_v1_geo_proto_rawDescOnce sync.Once
+	file_webgeo_v1_geo_proto_rawDescData []byte
+)
+
+func file_webgeo_v1_geo_proto_rawDescGZIP() []byte {
+	file_webgeo_v1_geo_proto_rawDescOnce.Do(func() {
+		file_webgeo_v1_geo_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_webgeo_v1_geo_proto_rawDesc), len(file_webgeo_v1_geo_proto_rawDesc)))
+	})
+	return file_webgeo_v1_geo_proto_rawDescData
+}
+
+var file_webgeo_v1_geo_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_webgeo_v1_geo_proto_goTypes = []any{
+	(*LookupRequest)(nil),       // 0: webgeo.v1.LookupRequest
+	(*LookupResponse)(nil),      // 1: webgeo.v1.LookupResponse
+	(*BatchLookupRequest)(nil),  // 2: webgeo.v1.BatchLookupRequest
+	(*BatchLookupResponse)(nil), // 3: webgeo.v1.BatchLookupResponse
+	(*NegotiateRequest)(nil),    // 4: webgeo.v1.NegotiateRequest
+	(*NegotiateResponse)(nil),   // 5: webgeo.v1.NegotiateResponse
+	(*Result)(nil),              // 6: webgeo.v1.Result
+	(*GeoRecord)(nil),           // 7: webgeo.v1.GeoRecord
+	(*Subdivision)(nil),         // 8: webgeo.v1.Subdivision
+	nil,                         // 9: webgeo.v1.Result.SourcesEntry
+}
+var file_webgeo_v1_geo_proto_depIdxs = []int32{
+	6,  // 0: webgeo.v1.LookupResponse.result:type_name -> webgeo.v1.Result
+	6,  // 1: webgeo.v1.BatchLookupResponse.results:type_name -> webgeo.v1.Result
+	6,  // 2: webgeo.v1.NegotiateResponse.result:type_name -> webgeo.v1.Result
+	9,  // 3: webgeo.v1.Result.sources:type_name -> webgeo.v1.Result.SourcesEntry
+	7,  // 4: webgeo.v1.Result.geo:type_name -> webgeo.v1.GeoRecord
+	8,  // 5: webgeo.v1.GeoRecord.subdivisions:type_name -> webgeo.v1.Subdivision
+	8,  // 6: webgeo.v1.GeoRecord.most_specific_subdivision:type_name -> webgeo.v1.Subdivision
+	0,  // 7: webgeo.v1.GeoService.Lookup:input_type -> webgeo.v1.LookupRequest
+	2,  // 8: webgeo.v1.GeoService.BatchLookup:input_type -> webgeo.v1.BatchLookupRequest
+	4,  // 9: webgeo.v1.GeoService.Negotiate:input_type -> webgeo.v1.NegotiateRequest
+	1,  // 10: webgeo.v1.GeoService.Lookup:output_type -> webgeo.v1.LookupResponse
+	3,  // 11: webgeo.v1.GeoService.BatchLookup:output_type -> webgeo.v1.BatchLookupResponse
+	5,  // 12: webgeo.v1.GeoService.Negotiate:output_type -> webgeo.v1.NegotiateResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_webgeo_v1_geo_proto_init() }
+func file_webgeo_v1_geo_proto_init() {
+	if File_webgeo_v1_geo_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_webgeo_v1_geo_proto_rawDesc), len(file_webgeo_v1_geo_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_webgeo_v1_geo_proto_goTypes,
+		DependencyIndexes: file_webgeo_v1_geo_proto_depIdxs,
+		MessageInfos:      file_webgeo_v1_geo_proto_msgTypes,
+	}.Build()
+	File_webgeo_v1_geo_proto = out.File
+	file_webgeo_v1_geo_proto_goTypes = nil
+	file_webgeo_v1_geo_proto_depIdxs = nil
+}
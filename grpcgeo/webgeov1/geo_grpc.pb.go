@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             v5.29.3
+// source: webgeo/v1/geo.proto
+
+package webgeov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GeoService_Lookup_FullMethodName      = "/webgeo.v1.GeoService/Lookup"
+	GeoService_BatchLookup_FullMethodName = "/webgeo.v1.GeoService/BatchLookup"
+	GeoService_Negotiate_FullMethodName   = "/webgeo.v1.GeoService/Negotiate"
+)
+
+// GeoServiceClient is the client API for GeoService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GeoService exposes webgeo to services written in other languages
+type GeoServiceClient interface {
+	// Lookup resolves the location of one IP. A broken database gives
+	// UNAVAILABLE, an IP that is not in the database country "ZZ".
+	Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error)
+	// BatchLookup is Lookup for many IPs, e.g. for log enrichment. Results
+	// are in the order of the IPs and invalid IPs get country "ZZ".
+	BatchLookup(ctx context.Context, in *BatchLookupRequest, opts ...grpc.CallOption) (*BatchLookupResponse, error)
+	// Negotiate resolves the country and languages of a client of the caller,
+	// like webgeo.Resolve for an HTTP request
+	Negotiate(ctx context.Context, in *NegotiateRequest, opts ...grpc.CallOption) (*NegotiateResponse, error)
+}
+
+type geoServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGeoServiceClient(cc grpc.ClientConnInterface) GeoServiceClient {
+	return &geoServiceClient{cc}
+}
+
+func (c *geoServiceClient) Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LookupResponse)
+	err := c.cc.Invoke(ctx, GeoService_Lookup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) BatchLookup(ctx context.Context, in *BatchLookupRequest, opts ...grpc.CallOption) (*BatchLookupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchLookupResponse)
+	err := c.cc.Invoke(ctx, GeoService_BatchLookup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) Negotiate(ctx context.Context, in *NegotiateRequest, opts ...grpc.CallOption) (*NegotiateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NegotiateResponse)
+	err := c.cc.Invoke(ctx, GeoService_Negotiate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GeoServiceServer is the server API for GeoService service.
+// All implementations must embed UnimplementedGeoServiceServer
+// for forward compatibility.
+//
+// GeoService exposes webgeo to services written in other languages
+type GeoServiceServer interface {
+	// Lookup resolves the location of one IP. A broken database gives
+	// UNAVAILABLE, an IP that is not in the database country "ZZ".
+	Lookup(context.Context, *LookupRequest) (*LookupResponse, error)
+	// BatchLookup is Lookup for many IPs, e.g. for log enrichment. Results
+	// are in the order of the IPs and invalid IPs get country "ZZ".
+	BatchLookup(context.Context, *BatchLookupRequest) (*BatchLookupResponse, error)
+	// Negotiate resolves the country and languages of a client of the caller,
+	// like webgeo.Resolve for an HTTP request
+	Negotiate(context.Context, *NegotiateRequest) (*NegotiateResponse, error)
+	mustEmbedUnimplementedGeoServiceServer()
+}
+
+// UnimplementedGeoServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGeoServiceServer struct{}
+
+func (UnimplementedGeoServiceServer) Lookup(context.Context, *LookupRequest) (*LookupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Lookup not implemented")
+}
+func (UnimplementedGeoServiceServer) BatchLookup(context.Context, *BatchLookupRequest) (*BatchLookupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchLookup not implemented")
+}
+func (UnimplementedGeoServiceServer) Negotiate(context.Context, *NegotiateRequest) (*NegotiateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Negotiate not implemented")
+}
+func (UnimplementedGeoServiceServer) mustEmbedUnimplementedGeoServiceServer() {}
+func (UnimplementedGeoServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeGeoServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GeoServiceServer will
+// result in compilation errors.
+type UnsafeGeoServiceServer interface {
+	mustEmbedUnimplementedGeoServiceServer()
+}
+
+func RegisterGeoServiceServer(s grpc.ServiceRegistrar, srv GeoServiceServer) {
+	// If the following call panics, it indicates UnimplementedGeoServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GeoService_ServiceDesc, srv)
+}
+
+func _GeoService_Lookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).Lookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_Lookup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).Lookup(ctx, req.(*LookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_BatchLookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchLookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).BatchLookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_BatchLookup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).BatchLookup(ctx, req.(*BatchLookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_Negotiate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NegotiateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).Negotiate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_Negotiate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).Negotiate(ctx, req.(*NegotiateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GeoService_ServiceDesc is the grpc.ServiceDesc for GeoService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GeoService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "webgeo.v1.GeoService",
+	HandlerType: (*GeoServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Lookup",
+			Handler:    _GeoService_Lookup_Handler,
+		},
+		{
+			MethodName: "BatchLookup",
+			Handler:    _GeoService_BatchLookup_Handler,
+		},
+		{
+			MethodName: "Negotiate",
+			Handler:    _GeoService_Negotiate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "webgeo/v1/geo.proto",
+}
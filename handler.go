@@ -0,0 +1,87 @@
+package webgeo
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// JavaScript identifiers and dotted paths, nothing that could inject code
+var jsonpCallbackRe = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*(\.[A-Za-z_$][A-Za-z0-9_$]*)*$`)
+
+// Handler serves the Result of the caller as JSON, e.g. mounted on /geoip
+// for frontends: location, negotiated languages and their sources. See
+// WithJSONP and WithCORS to call it from other sites.
+func Handler() http.Handler {
+	return defaultGeolocator.Handler()
+}
+
+func (g *Geolocator) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		if len(g.corsOrigins) > 0 {
+			AddVary(h, "Origin")
+			if origin := r.Header.Get("Origin"); origin != "" && g.corsAllowed(origin) {
+				h.Set("Access-Control-Allow-Origin", origin)
+				if r.Method == http.MethodOptions {
+					h.Set("Access-Control-Allow-Methods", "GET, HEAD")
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+			}
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			h.Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		// the answer is the caller's own
+		h.Set("Cache-Control", "private, no-store")
+		b, err := json.Marshal(g.Resolve(r))
+		if err != nil {
+			log.Printf("webgeo: could not encode result: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		callback := ""
+		if g.jsonpParam != "" {
+			callback = r.URL.Query().Get(g.jsonpParam)
+		}
+		if callback == "" {
+			h.Set("Content-Type", "application/json")
+			w.Write(append(b, '\n'))
+			return
+		}
+		if !jsonpCallbackRe.MatchString(callback) {
+			http.Error(w, "Invalid callback", http.StatusBadRequest)
+			return
+		}
+		h.Set("Content-Type", "application/javascript")
+		h.Set("X-Content-Type-Options", "nosniff")
+		// the leading comment defeats Flash-based JSONP attacks (Rosetta Flash)
+		w.Write([]byte("/**/" + callback + "(" + string(b) + ");\n"))
+	})
+}
+
+// WithJSONP makes Handler wrap its answer in the function named by the
+// query parameter param, e.g. ?callback=show, for legacy frontends
+func WithJSONP(param string) Option {
+	return func(g *Geolocator) { g.jsonpParam = param }
+}
+
+// WithCORS lets pages of the origins, e.g. "https://shop.example.com", call
+// Handler. "*" allows every origin.
+func WithCORS(origins ...string) Option {
+	return func(g *Geolocator) { g.corsOrigins = origins }
+}
+
+func (g *Geolocator) corsAllowed(origin string) bool {
+	for _, o := range g.corsOrigins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package webgeo
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	geoip2 "github.com/oschwald/geoip2-golang"
+)
+
+// StaleDBAge is the age of the database after which Health reports it as
+// stale. GeoLite2 is rebuilt twice a week, a month old copy means updates
+// stopped. Staleness doesn't make a Geolocator unready. 0 disables it.
+var StaleDBAge = 30 * 24 * time.Hour
+
+// Health tells whether a Geolocator can serve lookups
+type Health struct {
+	// the City database is open
+	Ready    bool   `json:"ready"`
+	Database DBInfo `json:"database"`
+	Age      string `json:"age,omitempty"` // of the database build
+	Stale    bool   `json:"stale"`         // older than StaleDBAge
+	// for the ASN database when ASNDBPath is set
+	ASNDatabase *DBInfo     `json:"asn_database,omitempty"`
+	Cache       CacheCounts `json:"cache"`
+}
+
+// CheckHealth opens the databases of the package functions if needed and
+// reports on them
+func CheckHealth() Health {
+	return defaultGeolocator.CheckHealth()
+}
+
+func (g *Geolocator) CheckHealth() Health {
+	h := Health{Database: DBInfo{Path: g.cityPath()}, Cache: g.CacheStats()}
+	err := g.withDB(func(db *geoip2.Reader) error {
+		h.Database.fill(db)
+		return nil
+	})
+	if err != nil {
+		h.Database.Error = err.Error()
+	} else {
+		h.Ready = true
+		age := time.Since(h.Database.BuildTime)
+		h.Age = age.Round(time.Hour).String()
+		h.Stale = StaleDBAge > 0 && age > StaleDBAge
+	}
+	if path := g.asnPath(); path != "" {
+		info := DBInfo{Path: path}
+		if err := g.withASNDB(func(db *geoip2.Reader) error {
+			info.fill(db)
+			return nil
+		}); err != nil {
+			info.Error = err.Error()
+		}
+		h.ASNDatabase = &info
+	}
+	return h
+}
+
+// HealthHandler serves CheckHealth as JSON with 200 OK when ready and 503
+// Service Unavailable when not, for readiness probes: pods without a
+// database get no traffic.
+func HealthHandler() http.Handler {
+	return defaultGeolocator.HealthHandler()
+}
+
+func (g *Geolocator) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := g.CheckHealth()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if !h.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(h); err != nil {
+			log.Printf("webgeo: could not write health: %v", err)
+		}
+	})
+}
@@ -0,0 +1,138 @@
+// Package ipinfogeo asks the ipinfo.io API about the IPs the local database
+// has no answer for, or about every IP when there is no database:
+//
+//	webgeo.FallbackProvider = ipinfogeo.New(os.Getenv("IPINFO_TOKEN"))
+//
+// Answers are cached by webgeo like the ones of the database, so the API is
+// called once per IP and CacheTTL.
+package ipinfogeo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seckiss/webgeo"
+)
+
+// DefaultURL is the ipinfo.io API
+const DefaultURL = "https://ipinfo.io"
+
+// Provider is a webgeo.Fallback backed by ipinfo.io
+type Provider struct {
+	// sent as a bearer token, never in the URL. Without one ipinfo.io
+	// allows a small number of requests per day.
+	Token string
+	// DefaultURL when empty
+	URL string
+	// a client with a 5s timeout when nil
+	Client *http.Client
+}
+
+var _ webgeo.Fallback = (*Provider)(nil)
+
+var defaultClient = &http.Client{Timeout: 5 * time.Second}
+
+var continentNames = map[string]string{
+	"AF": "Africa",
+	"AN": "Antarctica",
+	"AS": "Asia",
+	"EU": "Europe",
+	"NA": "North America",
+	"OC": "Oceania",
+	"SA": "South America",
+}
+
+// New returns a Provider using token
+func New(token string) *Provider {
+	return &Provider{Token: token}
+}
+
+// the response of ipinfo.io, see https://ipinfo.io/developers/responses
+type response struct {
+	IP       string `json:"ip"`
+	City     string `json:"city"`
+	Region   string `json:"region"`
+	Country  string `json:"country"`
+	Loc      string `json:"loc"` // "latitude,longitude"
+	Org      string `json:"org"` // "AS15169 Google LLC"
+	Timezone string `json:"timezone"`
+	Bogon    bool   `json:"bogon"`
+}
+
+// Locate returns nil for private and reserved IPs
+func (p *Provider) Locate(ip string) (*webgeo.GeoRecord, error) {
+	base := p.URL
+	if base == "" {
+		base = DefaultURL
+	}
+	client := p.Client
+	if client == nil {
+		client = defaultClient
+	}
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(base, "/")+"/"+url.PathEscape(ip)+"/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.Token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		return nil, fmt.Errorf("Could not query ipinfo.io for %s: %v", ip, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+		return nil, fmt.Errorf("Could not query ipinfo.io for %s: %s", ip, resp.Status)
+	}
+	var r response
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&r); err != nil {
+		return nil, fmt.Errorf("Invalid ipinfo.io response for %s: %v", ip, err)
+	}
+	if r.Bogon || r.Country == "" {
+		return nil, nil
+	}
+	return r.record(ip), nil
+}
+
+func (r *response) record(ip string) *webgeo.GeoRecord {
+	geo := &webgeo.GeoRecord{
+		Ip:       ip,
+		Cc:       r.Country,
+		City:     r.City,
+		TimeZone: r.Timezone,
+	}
+	if c := webgeo.CountryByCode(r.Country); c != nil {
+		geo.Country = c.Name
+		geo.ContinentCode = c.Continent
+		geo.Continent = continentNames[c.Continent]
+	}
+	if lat, lng, ok := strings.Cut(r.Loc, ","); ok {
+		geo.Latitude, _ = strconv.ParseFloat(lat, 64)
+		geo.Longitude, _ = strconv.ParseFloat(lng, 64)
+	}
+	if r.Region != "" {
+		// ipinfo.io only gives the name
+		geo.Subdivisions = []webgeo.Subdivision{{Name: r.Region}}
+		geo.MostSpecificSubdivision = geo.Subdivisions[0]
+	}
+	if as, org, _ := strings.Cut(r.Org, " "); strings.HasPrefix(as, "AS") {
+		if n, err := strconv.ParseUint(as[2:], 10, 32); err == nil {
+			geo.AutonomousSystemNumber = uint(n)
+			geo.AutonomousSystemOrganization = org
+		}
+	}
+	return geo
+}
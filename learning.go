@@ -0,0 +1,161 @@
+package webgeo
+
+import (
+	"encoding/json"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const langLearningKey = "lang-learning.json"
+
+// LangLearner adapts the geo-derived language defaults when enabled. nil
+// disables learning.
+var LangLearner *LangLearning
+
+// LangLearning learns per country which languages visitors explicitly switch
+// to after landing and ranks those ahead of the static country table. It is
+// an epsilon-greedy bandit: a share of requests still gets the static ranking
+// so the signal keeps coming for it, and counts decay so it follows changes.
+type LangLearning struct {
+	// switches needed in a country before its ranking is used
+	MinSamples float64
+	// languages tracked per country, the least chosen is forgotten first
+	MaxLangs int
+	// per country, all counts are halved when their sum exceeds it
+	MaxCount float64
+	// share of requests served the static ranking
+	Epsilon float64
+	// languages need this share of a country's switches to be promoted
+	MinShare float64
+
+	store  Store
+	mutex  sync.Mutex
+	counts map[string]map[string]float64
+}
+
+// NewLangLearning restores the learned state from store, which may be nil
+// for no persistence.
+func NewLangLearning(store Store) (*LangLearning, error) {
+	l := &LangLearning{
+		MinSamples: 50,
+		MaxLangs:   10,
+		MaxCount:   10000,
+		Epsilon:    0.1,
+		MinShare:   0.1,
+		store:      store,
+		counts:     make(map[string]map[string]float64),
+	}
+	if store == nil {
+		return l, nil
+	}
+	b, err := store.Load(langLearningKey)
+	if err != nil || b == nil {
+		return l, err
+	}
+	if err := json.Unmarshal(b, &l.counts); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// RecordSwitch notes that a visitor from country picked lang by hand
+func (l *LangLearning) RecordSwitch(country, lang string) {
+	tag, err := parseLang(lang)
+	if err != nil {
+		return
+	}
+	country = strings.ToUpper(country)
+	lang = tag.String()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	c := l.counts[country]
+	if c == nil {
+		c = make(map[string]float64)
+		l.counts[country] = c
+	}
+	if _, pres := c[lang]; !pres && len(c) >= l.MaxLangs {
+		least := ""
+		for k, v := range c {
+			if least == "" || v < c[least] {
+				least = k
+			}
+		}
+		delete(c, least)
+	}
+	c[lang]++
+	total := 0.0
+	for _, v := range c {
+		total += v
+	}
+	if total > l.MaxCount {
+		for k, v := range c {
+			if v/2 < 0.5 {
+				delete(c, k)
+			} else {
+				c[k] = v / 2
+			}
+		}
+	}
+}
+
+// Rank puts the learned languages of country ahead of the static
+// suggestions langs
+func (l *LangLearning) Rank(country string, langs []string) []string {
+	l.mutex.Lock()
+	c := l.counts[country]
+	total := 0.0
+	for _, v := range c {
+		total += v
+	}
+	learned := []string{}
+	for k, v := range c {
+		if v/total >= l.MinShare {
+			learned = append(learned, k)
+		}
+	}
+	sort.Slice(learned, func(i, j int) bool {
+		if c[learned[i]] != c[learned[j]] {
+			return c[learned[i]] > c[learned[j]]
+		}
+		return learned[i] < learned[j]
+	})
+	l.mutex.Unlock()
+
+	if total < l.MinSamples || rand.Float64() < l.Epsilon {
+		return langs
+	}
+	ranked := append([]string{}, learned...)
+	for _, s := range langs {
+		if !contains(ranked, s) {
+			ranked = append(ranked, s)
+		}
+	}
+	return ranked
+}
+
+// Save persists the learned state to the Store given to NewLangLearning.
+// Call it periodically and on shutdown.
+func (l *LangLearning) Save() error {
+	if l.store == nil {
+		return nil
+	}
+	l.mutex.Lock()
+	b, err := json.Marshal(l.counts)
+	l.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	return l.store.Save(langLearningKey, b)
+}
+
+func contains(l []string, s string) bool {
+	for _, e := range l {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package webgeo
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+type localeKey struct{}
+
+// LocalePrefix routes by a locale path prefix, e.g. /de/products or
+// /pt-BR/. Requests under the prefix of a supported locale go to next with
+// that locale in their context, see LocaleFromContext: the prefix is an
+// explicit choice and beats every other signal. GET requests without one are
+// redirected 302 Found to the prefix of BestLocale, the others go to next
+// with it. Paths whose first segment has a dot, like /robots.txt, are left
+// alone.
+func LocalePrefix(next http.Handler, supported ...language.Tag) http.Handler {
+	return defaultGeolocator.LocalePrefix(next, supported...)
+}
+
+func (g *Geolocator) LocalePrefix(next http.Handler, supported ...language.Tag) http.Handler {
+	prefixes := make(map[string]language.Tag)
+	for _, t := range supported {
+		prefixes[strings.ToLower(t.String())] = t
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		segment, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		if t, pres := prefixes[strings.ToLower(segment)]; pres {
+			next.ServeHTTP(w, r.WithContext(NewLocaleContext(r.Context(), t)))
+			return
+		}
+		if strings.Contains(segment, ".") || len(supported) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		t := g.BestLocale(r, supported)
+		AddVary(w.Header(), g.langVary()...)
+		if r.Method != http.MethodGet {
+			next.ServeHTTP(w, r.WithContext(NewLocaleContext(r.Context(), t)))
+			return
+		}
+		u := *r.URL
+		u.Path, u.RawPath = "/"+t.String()+r.URL.Path, ""
+		varyGeo(w.Header())
+		http.Redirect(w, r, u.String(), http.StatusFound)
+	})
+}
+
+// NewLocaleContext returns a copy of ctx carrying the locale t, so it is
+// negotiated first like a LocalePrefix one, e.g. for a locale chosen by
+// policy rules
+func NewLocaleContext(ctx context.Context, t language.Tag) context.Context {
+	return context.WithValue(ctx, localeKey{}, t)
+}
+
+// LocaleFromContext returns the locale chosen by LocalePrefix or given to
+// NewLocaleContext
+func LocaleFromContext(ctx context.Context) (language.Tag, bool) {
+	t, ok := ctx.Value(localeKey{}).(language.Tag)
+	return t, ok
+}
@@ -0,0 +1,79 @@
+package webgeo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+)
+
+// WithLocaleCookie makes Middleware remember the negotiated locale in the
+// cookie name, signed with key, so the following requests keep it even when
+// the visitor roams across borders or VPNs or changes browser settings: a
+// validly signed locale comes first in the languages, after explicit
+// choices, with SourceStored. The cookie follows explicit choices. key must
+// be secret, 32 random bytes are plenty.
+func WithLocaleCookie(name string, key []byte) Option {
+	if len(key) == 0 {
+		panic("webgeo: WithLocaleCookie needs a key")
+	}
+	return func(g *Geolocator) {
+		g.localeCookie = name
+		g.localeKey = append([]byte{}, key...)
+	}
+}
+
+// The locale of the signed WithLocaleCookie cookie, "" when there is none
+// or the signature doesn't match
+func (g *Geolocator) storedLocale(r *http.Request) string {
+	if g.localeCookie == "" {
+		return ""
+	}
+	c, err := r.Cookie(g.localeCookie)
+	if err != nil {
+		return ""
+	}
+	i := strings.LastIndexByte(c.Value, '.')
+	if i < 0 || !hmac.Equal([]byte(c.Value), []byte(g.signLocale(c.Value[:i]))) {
+		return ""
+	}
+	t, err := parseLang(c.Value[:i])
+	if err != nil {
+		return ""
+	}
+	return t.String()
+}
+
+// Languages stored by WithLocaleCookie
+func (g *Geolocator) storedLangs(r *http.Request) []string {
+	if l := g.storedLocale(r); l != "" {
+		return []string{l}
+	}
+	return []string{}
+}
+
+// locale.signature, the name is signed too so a value can't be moved to
+// another cookie
+func (g *Geolocator) signLocale(locale string) string {
+	mac := hmac.New(sha256.New, g.localeKey)
+	mac.Write([]byte(g.localeCookie + "=" + locale))
+	return locale + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Set the WithLocaleCookie cookie to the preferred language of res, unless
+// it holds it already
+func (g *Geolocator) saveLocale(w http.ResponseWriter, r *http.Request, res Result) {
+	if g.localeCookie == "" || len(res.Langs) == 0 || g.storedLocale(r) == res.Langs[0] {
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     g.localeCookie,
+		Value:    g.signLocale(res.Langs[0]),
+		Path:     "/",
+		MaxAge:   langCookieMaxAge,
+		Secure:   r.TLS != nil,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,59 @@
+package webgeo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStoredLocale(t *testing.T) {
+	g := New(WithLocaleCookie("locale", []byte("0123456789abcdef0123456789abcdef")))
+	other := New(WithLocaleCookie("locale", []byte("another key")))
+	renamed := New(WithLocaleCookie("lang", []byte("0123456789abcdef0123456789abcdef")))
+	valid := g.signLocale("de-AT")
+	tests := []struct {
+		name, value, want string
+	}{
+		{"valid", valid, "de-AT"},
+		{"tampered locale", "fr" + valid[len("de-AT"):], ""},
+		{"tampered signature", valid[:len(valid)-1] + "A", ""},
+		{"other key", other.signLocale("de-AT"), ""},
+		{"other cookie name", renamed.signLocale("de-AT"), ""},
+		{"unsigned", "de-AT", ""},
+		{"empty signature", "de-AT.", ""},
+		{"signed garbage", g.signLocale("not a tag!"), ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.AddCookie(&http.Cookie{Name: "locale", Value: tt.value})
+		if got := g.storedLocale(r); got != tt.want {
+			t.Errorf("%s: %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if got := New().storedLocale(httptest.NewRequest("GET", "/", nil)); got != "" {
+		t.Errorf("without WithLocaleCookie: %q", got)
+	}
+}
+
+func TestSaveLocale(t *testing.T) {
+	g := New(WithLocaleCookie("locale", []byte("key")))
+	r := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	g.saveLocale(w, r, Result{Langs: []string{"pl", "en"}})
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || !cookies[0].HttpOnly || cookies[0].Value != g.signLocale("pl") {
+		t.Fatalf("cookies %v", cookies)
+	}
+
+	// a request with the same locale stored doesn't get the cookie again
+	r.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	g.saveLocale(w, r, Result{Langs: []string{"pl"}})
+	if len(w.Result().Cookies()) != 0 {
+		t.Errorf("cookie set again")
+	}
+	if langs := g.storedLangs(r); len(langs) != 1 || langs[0] != "pl" {
+		t.Errorf("storedLangs %v", langs)
+	}
+}
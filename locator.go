@@ -0,0 +1,42 @@
+package webgeo
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+
+	"golang.org/x/text/language"
+)
+
+// Locator is what handlers need from a Geolocator. Depend on it instead of
+// *Geolocator to inject a fake in unit tests.
+type Locator interface {
+	// GeoRecord of addr, nil when the lookup failed, with an empty Cc when
+	// addr is not in the database
+	Lookup(ctx context.Context, addr netip.Addr) (*GeoRecord, error)
+	// languages of the request, browser ones and the ones of the country
+	Langs(r *http.Request) ([]language.Tag, error)
+}
+
+var _ Locator = (*Geolocator)(nil)
+
+// Lookup is LookupCtx for a parsed address
+func (g *Geolocator) Lookup(ctx context.Context, addr netip.Addr) (*GeoRecord, error) {
+	if !addr.IsValid() {
+		return nil, ErrNoClientIP
+	}
+	return g.lookupCtx(ctx, addr.Unmap().String())
+}
+
+// Langs returns the languages of CalcCountryAndLangs as tags, with the error
+// of CalcCountryAndLangsE. The languages are filled either way.
+func (g *Geolocator) Langs(r *http.Request) ([]language.Tag, error) {
+	tags := []language.Tag{}
+	res, err := g.calcCountryAndLangs(r)
+	for _, l := range res.Langs {
+		if t, err := language.Parse(l); err == nil {
+			tags = append(tags, t)
+		}
+	}
+	return tags, err
+}
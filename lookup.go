@@ -0,0 +1,17 @@
+package webgeo
+
+import (
+	"context"
+	"net/netip"
+)
+
+// Lookup returns the GeoRecord of ip for consumers without an http.Request,
+// e.g. log processors. Results are cached like for requests. See LookupCtx.
+func Lookup(ip string) (*GeoRecord, error) {
+	return LookupCtx(context.Background(), ip)
+}
+
+// LookupAddr is Lookup for a parsed address
+func LookupAddr(addr netip.Addr) (*GeoRecord, error) {
+	return defaultGeolocator.Lookup(context.Background(), addr)
+}
@@ -0,0 +1,58 @@
+package webgeo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Revisions of the bundled datasets. Bump the revision whenever the data
+// changes so that decisions can be traced back to the data that made them.
+const (
+	countryTableRevision    = 1
+	privacyRegimesRevision  = 2
+	blockedServicesRevision = 1
+	consentDefaultsRevision = 1
+)
+
+// Hashes of the configurable datasets as shipped, to tell whether the
+// application replaced them
+var defaultBlockedServicesHash = hashJSON(BlockedServices)
+var defaultConsentDefaultsHash = hashJSON(ConsentModeDefaults)
+
+// DataSet describes one dataset that affects locale decisions
+type DataSet struct {
+	Name     string `json:"name"`
+	Revision int    `json:"revision"`
+	SHA256   string `json:"sha256"`
+	Entries  int    `json:"entries"`
+	// set when the application changed the bundled data at runtime
+	Modified bool `json:"modified,omitempty"`
+}
+
+// DataManifest lists the datasets in effect with the hashes of their current
+// contents. Log it along with decisions that customers may dispute.
+func DataManifest() []DataSet {
+	blocked := hashJSON(BlockedServices)
+	consent := hashJSON(ConsentModeDefaults)
+	return []DataSet{
+		{"country-table", countryTableRevision, hashString(countryInfoTable), len(countryList), false},
+		{"privacy-regimes", privacyRegimesRevision, hashJSON(regimeCountries), len(regimeCountries), false},
+		{"blocked-services", blockedServicesRevision, blocked, len(BlockedServices), blocked != defaultBlockedServicesHash},
+		{"consent-defaults", consentDefaultsRevision, consent, len(ConsentModeDefaults), consent != defaultConsentDefaultsHash},
+	}
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// maps are marshalled with sorted keys so the hash is stable
+func hashJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return hashString(string(b))
+}
@@ -0,0 +1,135 @@
+// Package memcachegeo keeps webgeo lookups in memcached, so a fleet of
+// short-lived processes shares one warm cache:
+//
+//	g := webgeo.New(webgeo.WithCache(memcachegeo.New("10.0.0.1:11211")))
+package memcachegeo
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/seckiss/webgeo"
+)
+
+// Cache is a webgeo.Cache backed by memcached
+type Cache struct {
+	Client *memcache.Client
+	// prepended to the IP to make the key, "webgeo:" when empty
+	Prefix string
+	// how long entries live, 0 means the webgeo.CacheEntry.Lifetime given
+	// by the Geolocator. Negative entries don't live longer than it allows.
+	TTL time.Duration
+}
+
+var _ webgeo.Cache = (*Cache)(nil)
+
+// New returns a Cache on the memcached servers
+func New(servers ...string) *Cache {
+	return &Cache{Client: memcache.New(servers...)}
+}
+
+// the stored form of a webgeo.CacheEntry
+type entry struct {
+	Geo *webgeo.GeoRecord `json:"geo,omitempty"`
+	Err string            `json:"err,omitempty"`
+}
+
+func (c *Cache) key(ip string) string {
+	if c.Prefix == "" {
+		return "webgeo:" + ip
+	}
+	return c.Prefix + ip
+}
+
+// Get treats an unreachable memcached as a miss
+func (c *Cache) Get(ip string) (webgeo.CacheEntry, bool) {
+	item, err := c.Client.Get(c.key(ip))
+	if err != nil {
+		if err != memcache.ErrCacheMiss {
+			log.Printf("webgeo: memcache get %s: %v", ip, err)
+		}
+		return webgeo.CacheEntry{}, false
+	}
+	var e entry
+	if err := json.Unmarshal(item.Value, &e); err != nil {
+		log.Printf("webgeo: memcache get %s: %v", ip, err)
+		return webgeo.CacheEntry{}, false
+	}
+	ce := webgeo.CacheEntry{Geo: e.Geo}
+	if e.Err != "" {
+		ce.Err = errors.New(e.Err)
+	}
+	return ce, true
+}
+
+// memcached takes expirations over 30 days as a Unix time
+const maxRelativeExpiration = 30 * 24 * time.Hour
+
+func (c *Cache) Set(ip string, ce webgeo.CacheEntry) {
+	ttl := c.lifetime(ce)
+	if ttl < 0 {
+		return
+	}
+	e := entry{Geo: ce.Geo}
+	if ce.Err != nil {
+		e.Err = ce.Err.Error()
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		log.Printf("webgeo: memcache set %s: %v", ip, err)
+		return
+	}
+	item := &memcache.Item{Key: c.key(ip), Value: b, Expiration: expiration(ttl, time.Now())}
+	if err := c.Client.Set(item); err != nil {
+		log.Printf("webgeo: memcache set %s: %v", ip, err)
+	}
+}
+
+// how long ce lives, 0 forever and less than 0 not at all
+func (c *Cache) lifetime(ce webgeo.CacheEntry) time.Duration {
+	ttl, ok := ce.Lifetime()
+	if !ok {
+		// not from a Geolocator, use the package defaults
+		ttl = webgeo.CacheTTL
+		if ce.Negative() {
+			ttl = webgeo.NegativeCacheTTL
+			if ttl <= 0 {
+				return -1
+			}
+		}
+	}
+	if c.TTL > 0 && ttl >= 0 && (!ce.Negative() || ttl == 0 || c.TTL < ttl) {
+		ttl = c.TTL
+	}
+	return ttl
+}
+
+// the memcached expiration of ttl from now: seconds up to 30 days, a Unix
+// time beyond, 0 never
+func expiration(ttl time.Duration, now time.Time) int32 {
+	if ttl <= 0 {
+		return 0
+	}
+	if ttl > maxRelativeExpiration {
+		return int32(now.Add(ttl).Unix())
+	}
+	if ttl < time.Second {
+		// 0 would be never
+		return 1
+	}
+	return int32(ttl / time.Second)
+}
+
+func (c *Cache) Delete(ip string) {
+	if err := c.Client.Delete(c.key(ip)); err != nil && err != memcache.ErrCacheMiss {
+		log.Printf("webgeo: memcache delete %s: %v", ip, err)
+	}
+}
+
+// Len is always -1: memcached doesn't count the keys of a prefix
+func (c *Cache) Len() int {
+	return -1
+}
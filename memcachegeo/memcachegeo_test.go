@@ -0,0 +1,63 @@
+package memcachegeo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seckiss/webgeo"
+)
+
+func TestExpiration(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		ttl  time.Duration
+		want int32
+	}{
+		{0, 0},
+		{-time.Second, 0},
+		{time.Millisecond, 1},
+		{time.Minute, 60},
+		{30 * 24 * time.Hour, 2592000},
+		{31 * 24 * time.Hour, 1700000000 + 31*24*3600},
+		{365 * 24 * time.Hour, 1700000000 + 365*24*3600},
+	}
+	for _, tt := range tests {
+		if got := expiration(tt.ttl, now); got != tt.want {
+			t.Errorf("expiration(%v) = %d, want %d", tt.ttl, got, tt.want)
+		}
+	}
+}
+
+// a webgeo.Cache keeping the last entry set
+type lastEntry struct{ e webgeo.CacheEntry }
+
+func (c *lastEntry) Get(ip string) (webgeo.CacheEntry, bool) { return webgeo.CacheEntry{}, false }
+func (c *lastEntry) Set(ip string, e webgeo.CacheEntry)      { c.e = e }
+func (c *lastEntry) Delete(ip string)                        {}
+func (c *lastEntry) Len() int                                { return 0 }
+
+func TestLifetime(t *testing.T) {
+	export := `{"webgeo_cache":1}
+{"ip":"192.0.2.1","geo":{"ip":"192.0.2.1","cc":"DE"}}
+`
+	last := &lastEntry{}
+	g := webgeo.New(webgeo.WithCache(last), webgeo.WithCacheTTL(90*24*time.Hour))
+	if _, err := g.ImportCache(strings.NewReader(export)); err != nil {
+		t.Fatal(err)
+	}
+	if got := (&Cache{}).lifetime(last.e); got != 90*24*time.Hour {
+		t.Errorf("lifetime %v, want the WithCacheTTL of the Geolocator", got)
+	}
+	if got := (&Cache{TTL: time.Hour}).lifetime(last.e); got != time.Hour {
+		t.Errorf("lifetime %v, want the TTL of the Cache", got)
+	}
+
+	negative := webgeo.CacheEntry{Geo: &webgeo.GeoRecord{}}
+	if got := (&Cache{TTL: time.Hour}).lifetime(negative); got != webgeo.NegativeCacheTTL {
+		t.Errorf("negative lifetime %v, want NegativeCacheTTL", got)
+	}
+	if got := (&Cache{}).lifetime(webgeo.CacheEntry{Geo: &webgeo.GeoRecord{Cc: "DE"}}); got != webgeo.CacheTTL {
+		t.Errorf("lifetime %v of an entry without Lifetime, want CacheTTL", got)
+	}
+}
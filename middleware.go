@@ -0,0 +1,99 @@
+package webgeo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type resultKey struct{}
+
+// Middleware resolves each request once and passes it to next with the
+// Result in its context, see ResultFromContext, so handlers don't each
+// repeat the lookup and the language negotiation. Requests with SkipMethods
+// only get a Result when the location is already cached. A WithLangParam
+// choice is saved to the WithLangCookie cookie, the negotiated locale to the
+// WithLocaleCookie one. The response varies on Accept-Language, on Cookie
+// with either cookie and on GeoVary.
+func Middleware(next http.Handler) http.Handler {
+	return defaultGeolocator.Middleware(next)
+}
+
+func (g *Geolocator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.SaveLangParam(w, r)
+		AddVary(w.Header(), append(g.langVary(), GeoVary)...)
+		start := time.Now()
+		res, ok := g.MiddlewareResolve(r)
+		if g.serverTiming {
+			w.Header().Add("Server-Timing", fmt.Sprintf("geo;dur=%.1f", float64(time.Since(start))/float64(time.Millisecond)))
+		}
+		if ok {
+			if g.contentLang && len(res.Langs) > 0 && w.Header().Get("Content-Language") == "" {
+				w.Header().Set("Content-Language", res.Langs[0])
+			}
+			g.saveLocale(w, r, res)
+			r = r.WithContext(NewContext(r.Context(), res))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WithContentLanguage makes Middleware set the Content-Language response
+// header to the preferred language, unless already set. Handlers serving
+// another language overwrite it. See Result.HTMLAttrs for the page itself.
+func WithContentLanguage() Option {
+	return func(g *Geolocator) { g.contentLang = true }
+}
+
+// WithServerTiming makes Middleware report the time spent resolving the
+// request in the Server-Timing response header, e.g. geo;dur=1.2 in
+// milliseconds, for the performance tools of browsers and frontends
+func WithServerTiming() Option {
+	return func(g *Geolocator) { g.serverTiming = true }
+}
+
+// NewContext returns a copy of ctx carrying res, e.g. for tests of handlers
+// behind Middleware
+func NewContext(ctx context.Context, res Result) context.Context {
+	return context.WithValue(ctx, resultKey{}, res)
+}
+
+// ResultFromContext returns the Result stored by Middleware
+func ResultFromContext(ctx context.Context) (Result, bool) {
+	res, ok := ctx.Value(resultKey{}).(Result)
+	return res, ok
+}
+
+// GeoFromContext returns the location stored by Middleware. ok is false
+// when there is none, because the lookup failed or Middleware didn't run.
+// The location of an IP missing from the database has an empty Cc.
+func GeoFromContext(ctx context.Context) (geo *GeoRecord, ok bool) {
+	res, ok := ResultFromContext(ctx)
+	if !ok || res.Geo == nil {
+		return nil, false
+	}
+	return res.Geo, true
+}
+
+// MustGeoFromContext is GeoFromContext for handlers that are always behind
+// Middleware. It panics when Middleware didn't run and returns an empty
+// GeoRecord when the lookup failed.
+func MustGeoFromContext(ctx context.Context) *GeoRecord {
+	res, ok := ResultFromContext(ctx)
+	if !ok {
+		panic("webgeo: no Result in the context, is the handler behind Middleware?")
+	}
+	if res.Geo == nil {
+		return &GeoRecord{}
+	}
+	return res.Geo
+}
+
+// LangsFromContext returns the languages negotiated by Middleware, most
+// preferred first. ok is false when Middleware didn't run.
+func LangsFromContext(ctx context.Context) (langs []string, ok bool) {
+	res, ok := ResultFromContext(ctx)
+	return res.Langs, ok
+}
@@ -0,0 +1,35 @@
+package webgeo
+
+import (
+	"net/http"
+
+	"golang.org/x/text/language"
+)
+
+// BestLocale picks the best of the locales the application supports for the
+// request, matching the languages of CalcCountryAndLangs in order with
+// language.Matcher. Returns supported[0] when nothing matches.
+func BestLocale(r *http.Request, supported []language.Tag) language.Tag {
+	return defaultGeolocator.BestLocale(r, supported)
+}
+
+func (g *Geolocator) BestLocale(r *http.Request, supported []language.Tag) language.Tag {
+	if len(supported) == 0 {
+		return language.Und
+	}
+	m := language.NewMatcher(supported)
+	_, i, _ := m.Match(g.preferredLangs(r)...)
+	return supported[i]
+}
+
+// Languages of the request in order of preference
+func (g *Geolocator) preferredLangs(r *http.Request) []language.Tag {
+	tags := []language.Tag{}
+	res, _ := g.mergeLangs(r)
+	for _, l := range res.Langs {
+		if t, err := language.Parse(l); err == nil {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
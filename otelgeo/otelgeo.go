@@ -0,0 +1,154 @@
+// Package otelgeo attaches webgeo results to OpenTelemetry spans, so tracing
+// backends can slice latency by market.
+package otelgeo
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/seckiss/webgeo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/text/language"
+)
+
+const (
+	Country   = "geo.country"
+	Continent = "geo.continent"
+	City      = "geo.city"
+	Language  = "user.language"
+)
+
+// Other replaces values beyond the cardinality limits
+const Other = "other"
+
+// Config decides which attributes are set and bounds their cardinality
+type Config struct {
+	// Attributes to set, nil means DefaultAttributes
+	Attributes []string
+	// Countries reported as themselves, the rest as Other. nil allows all.
+	Countries []string
+	// distinct cities reported before the rest becomes Other, 0 reports
+	// every city as Other
+	MaxCities int
+	// report the full language tag (de-AT) instead of the base language (de)
+	FullLanguageTags bool
+
+	mutex  sync.Mutex
+	cities map[string]bool
+}
+
+// City is left out by default: it has by far the highest cardinality
+var DefaultAttributes = []string{Country, Continent, Language}
+
+// used for a nil *Config, shared so its city limit holds across calls
+var defaultConfig = &Config{}
+
+func (cfg *Config) orDefault() *Config {
+	if cfg == nil {
+		return defaultConfig
+	}
+	return cfg
+}
+
+// Annotate sets the allowed attributes of res on the span in ctx. A nil cfg
+// is the zero Config.
+func Annotate(ctx context.Context, res webgeo.Result, cfg *Config) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.SetAttributes(cfg.orDefault().attributes(res)...)
+}
+
+// Middleware annotates the active span of each request. Put it inside the
+// tracing middleware so a span already exists. A nil cfg is the zero Config.
+func Middleware(next http.Handler, cfg *Config) http.Handler {
+	return MiddlewareFor(nil, next, cfg)
+}
+
+// MiddlewareFor is Middleware resolving requests with g, or the package
+// settings when g is nil
+func MiddlewareFor(g *webgeo.Geolocator, next http.Handler, cfg *Config) http.Handler {
+	resolve := webgeo.MiddlewareResolve
+	if g != nil {
+		resolve = g.MiddlewareResolve
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if trace.SpanFromContext(r.Context()).IsRecording() {
+			if res, ok := resolve(r); ok {
+				Annotate(r.Context(), res, cfg)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (cfg *Config) attributes(res webgeo.Result) []attribute.KeyValue {
+	keys := cfg.Attributes
+	if keys == nil {
+		keys = DefaultAttributes
+	}
+	attrs := []attribute.KeyValue{}
+	for _, k := range keys {
+		switch k {
+		case Country:
+			attrs = append(attrs, attribute.String(Country, cfg.country(res.Country)))
+		case Continent:
+			if res.Geo != nil && res.Geo.ContinentCode != "" {
+				attrs = append(attrs, attribute.String(Continent, res.Geo.ContinentCode))
+			}
+		case City:
+			if res.Geo != nil && res.Geo.City != "" {
+				attrs = append(attrs, attribute.String(City, cfg.city(res.Geo.City)))
+			}
+		case Language:
+			if len(res.Langs) > 0 {
+				attrs = append(attrs, attribute.String(Language, cfg.language(res.Langs[0])))
+			}
+		}
+	}
+	return attrs
+}
+
+func (cfg *Config) country(cc string) string {
+	if cfg.Countries == nil {
+		return cc
+	}
+	for _, c := range cfg.Countries {
+		if strings.EqualFold(c, cc) {
+			return cc
+		}
+	}
+	return Other
+}
+
+func (cfg *Config) city(city string) string {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	if cfg.cities == nil {
+		cfg.cities = make(map[string]bool)
+	}
+	if cfg.cities[city] {
+		return city
+	}
+	if len(cfg.cities) >= cfg.MaxCities {
+		return Other
+	}
+	cfg.cities[city] = true
+	return city
+}
+
+func (cfg *Config) language(l string) string {
+	if cfg.FullLanguageTags {
+		return l
+	}
+	t, err := language.Parse(l)
+	if err != nil {
+		return Other
+	}
+	base, _ := t.Base()
+	return base.String()
+}
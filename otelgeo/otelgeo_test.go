@@ -0,0 +1,48 @@
+package otelgeo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/seckiss/webgeo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestNilConfig(t *testing.T) {
+	res := webgeo.Result{Country: "DE", Langs: []string{"de-AT"}, Geo: &webgeo.GeoRecord{ContinentCode: "EU", City: "Wien"}}
+	attrs := (*Config)(nil).orDefault().attributes(res)
+	want := []attribute.KeyValue{
+		attribute.String(Country, "DE"),
+		attribute.String(Continent, "EU"),
+		attribute.String(Language, "de"),
+	}
+	if len(attrs) != len(want) {
+		t.Fatalf("attributes %v, want %v", attrs, want)
+	}
+	for i := range want {
+		if attrs[i] != want[i] {
+			t.Errorf("attribute %d: %v, want %v", i, attrs[i], want[i])
+		}
+	}
+	Annotate(context.Background(), res, nil)
+	r := httptest.NewRequest("GET", "/", nil)
+	Middleware(http.NotFoundHandler(), nil).ServeHTTP(httptest.NewRecorder(), r)
+}
+
+func TestCardinality(t *testing.T) {
+	cfg := &Config{Attributes: []string{Country, City}, Countries: []string{"de"}, MaxCities: 1}
+	for _, tt := range []struct {
+		country, city, wantCountry, wantCity string
+	}{
+		{"DE", "Berlin", "DE", "Berlin"},
+		{"FR", "Paris", Other, Other},
+		{"DE", "Berlin", "DE", "Berlin"},
+	} {
+		attrs := cfg.attributes(webgeo.Result{Country: tt.country, Geo: &webgeo.GeoRecord{City: tt.city}})
+		if attrs[0].Value.AsString() != tt.wantCountry || attrs[1].Value.AsString() != tt.wantCity {
+			t.Errorf("%s %s: %v", tt.country, tt.city, attrs)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package webgeo
+
+import "net/http"
+
+// a year, in seconds
+const langCookieMaxAge = 365 * 24 * 3600
+
+// WithLangCookie honors the cookie name, e.g. "lang" holding "de", as the
+// language the visitor picked. A valid BCP 47 value comes first in the
+// languages, ahead of the browser and the country ones. Invalid values are
+// ignored.
+func WithLangCookie(name string) Option {
+	return func(g *Geolocator) { g.langCookie = name }
+}
+
+// WithLangParam honors the query parameter name, e.g. "hl" in ?hl=fr, as
+// the language of the request, for links that must land in a language. It
+// takes precedence over WithLangCookie, see SaveLangParam to persist it.
+func WithLangParam(name string) Option {
+	return func(g *Geolocator) { g.langParam = name }
+}
+
+// SaveLangParam sets the WithLangCookie cookie to the valid WithLangParam
+// language of the request, so the choice sticks on the following pages.
+// Returns false when there is nothing to save.
+func (g *Geolocator) SaveLangParam(w http.ResponseWriter, r *http.Request) bool {
+	if g.langParam == "" || g.langCookie == "" {
+		return false
+	}
+	t, err := parseLang(r.URL.Query().Get(g.langParam))
+	if err != nil {
+		return false
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     g.langCookie,
+		Value:    t.String(),
+		Path:     "/",
+		MaxAge:   langCookieMaxAge,
+		Secure:   r.TLS != nil,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return true
+}
+
+// Languages chosen explicitly by the visitor, the most explicit first
+func (g *Geolocator) overrideLangs(r *http.Request) []string {
+	langs := []string{}
+	if t, ok := LocaleFromContext(r.Context()); ok {
+		langs = append(langs, canonical(t).String())
+	}
+	if g.langParam != "" && r.URL != nil {
+		if t, err := parseLang(r.URL.Query().Get(g.langParam)); err == nil {
+			langs = append(langs, t.String())
+		}
+	}
+	if g.langCookie != "" {
+		if c, err := r.Cookie(g.langCookie); err == nil {
+			if t, err := parseLang(c.Value); err == nil {
+				langs = append(langs, t.String())
+			}
+		}
+	}
+	return langs
+}
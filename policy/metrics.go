@@ -0,0 +1,78 @@
+package policy
+
+import (
+	"log"
+	"math/rand"
+	"net/http"
+	"net/netip"
+
+	"github.com/seckiss/webgeo"
+)
+
+// Metrics receives rule matches, e.g. to increment a Prometheus counter
+// labelled by rule and action
+type Metrics interface {
+	RuleMatched(rule string, action Action)
+}
+
+// Audit logs a sample of the decisions with anonymized client IPs: the last
+// octet of IPv4 and all but the first 48 bits of IPv6 addresses are zeroed.
+type Audit struct {
+	// fraction of the requests logged, 0.01 logs about one in a hundred
+	Sample float64
+	// nil for the standard logger
+	Logger *log.Logger
+}
+
+func (p *Policy) count(rule Rule) {
+	p.mutex.Lock()
+	if p.counts == nil {
+		p.counts = make(map[string]uint64)
+	}
+	p.counts[rule.Name]++
+	p.mutex.Unlock()
+	if p.Metrics != nil {
+		p.Metrics.RuleMatched(rule.Name, rule.Action)
+	}
+}
+
+// Counts returns how many times each rule matched since the policy was
+// loaded, by rule name
+func (p *Policy) Counts() map[string]uint64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	counts := make(map[string]uint64, len(p.counts))
+	for name, n := range p.counts {
+		counts[name] = n
+	}
+	return counts
+}
+
+func (a *Audit) log(r *http.Request, res webgeo.Result, d Decision) {
+	if a == nil || rand.Float64() >= a.Sample {
+		return
+	}
+	ip := "-"
+	if res.Geo != nil {
+		ip = anonymizeIP(res.Geo.Ip)
+	}
+	logf := log.Printf
+	if a.Logger != nil {
+		logf = a.Logger.Printf
+	}
+	logf("webgeo: policy: %s %s from %s (%s) rule=%q matched=%q", d.Action, r.URL.Path, ip, res.Country, d.Rule, d.Matched)
+}
+
+func anonymizeIP(ipS string) string {
+	addr, err := netip.ParseAddr(ipS)
+	if err != nil {
+		return "-"
+	}
+	addr = addr.Unmap()
+	bits := 48
+	if addr.Is4() {
+		bits = 24
+	}
+	p, _ := addr.Prefix(bits)
+	return p.Addr().String()
+}
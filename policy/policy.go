@@ -0,0 +1,263 @@
+// Package policy evaluates ordered rules over webgeo results, so blocking,
+// locale overrides and tagging live in one configuration that can be
+// reviewed and audited:
+//
+//	rules:
+//	  - name: block anonymous proxies
+//	    anonymous: true
+//	    action: block
+//	  - name: swiss french
+//	    countries: [CH]
+//	    action: set-locale
+//	    locale: fr-CH
+//	  - name: eu traffic
+//	    continents: [EU]
+//	    action: tag
+//	    tag: eu
+//
+// Rules are evaluated in order. allow and block end the evaluation,
+// set-locale and tag apply and go on with the next rule.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/seckiss/webgeo"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+type Action string
+
+const (
+	Allow     Action = "allow"
+	Block     Action = "block"
+	SetLocale Action = "set-locale"
+	Tag       Action = "tag"
+)
+
+// Rule matches when all its conditions match. A list condition matches any
+// of its elements, an empty one matches everything.
+type Rule struct {
+	Name       string   `yaml:"name"`
+	Countries  []string `yaml:"countries"`
+	Continents []string `yaml:"continents"`
+	// needs webgeo.ASNDBPath
+	ASNs []uint `yaml:"asns"`
+	// traits from the database, nil matches both
+	Anonymous *bool `yaml:"anonymous"`
+	Satellite *bool `yaml:"satellite"`
+
+	Action Action `yaml:"action"`
+	Locale string `yaml:"locale"` // for set-locale
+	Tag    string `yaml:"tag"`    // for tag
+}
+
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+
+	// optional, told about every matching rule
+	Metrics Metrics `yaml:"-"`
+	// optional, logs a sample of the decisions made by Middleware
+	Audit *Audit `yaml:"-"`
+
+	mutex  sync.Mutex
+	counts map[string]uint64
+}
+
+// Decision is the outcome of a Policy for a request
+type Decision struct {
+	Action Action `json:"action"` // Allow or Block
+	// name of the rule that ended the evaluation, "" when none did
+	Rule   string   `json:"rule,omitempty"`
+	Locale string   `json:"locale,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+	// names of all matching rules in order
+	Matched []string `json:"matched"`
+}
+
+// Load reads a YAML policy and validates it
+func Load(r io.Reader) (*Policy, error) {
+	p := &Policy{}
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(p); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// LoadFile is Load from a file
+func LoadFile(path string) (*Policy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p, err := Load(f)
+	if err != nil {
+		return nil, fmt.Errorf("Could not load policy %s: %v", path, err)
+	}
+	return p, nil
+}
+
+func (p *Policy) validate() error {
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		for j, c := range rule.Countries {
+			rule.Countries[j] = strings.ToUpper(c)
+		}
+		for j, c := range rule.Continents {
+			rule.Continents[j] = strings.ToUpper(c)
+		}
+		switch rule.Action {
+		case Allow, Block:
+		case SetLocale:
+			t, err := language.Parse(rule.Locale)
+			if err != nil {
+				return fmt.Errorf("Invalid locale %q in %s", rule.Locale, rule.Name)
+			}
+			rule.Locale = t.String()
+		case Tag:
+			if rule.Tag == "" {
+				return fmt.Errorf("Missing tag in %s", rule.Name)
+			}
+		default:
+			return fmt.Errorf("Unknown action %q in %s", rule.Action, rule.Name)
+		}
+	}
+	return nil
+}
+
+// Evaluate the rules for res. Without a matching allow or block rule the
+// request is allowed.
+func (p *Policy) Evaluate(res webgeo.Result) Decision {
+	d := Decision{Action: Allow, Matched: []string{}}
+	for _, rule := range p.Rules {
+		if !rule.matches(res) {
+			continue
+		}
+		d.Matched = append(d.Matched, rule.Name)
+		p.count(rule)
+		switch rule.Action {
+		case SetLocale:
+			d.Locale = rule.Locale
+		case Tag:
+			d.Tags = append(d.Tags, rule.Tag)
+		default:
+			d.Action, d.Rule = rule.Action, rule.Name
+			return d
+		}
+	}
+	return d
+}
+
+func (rule *Rule) matches(res webgeo.Result) bool {
+	geo := res.Geo
+	if geo == nil {
+		geo = &webgeo.GeoRecord{}
+	}
+	if len(rule.Countries) > 0 && !contains(rule.Countries, res.Country) {
+		return false
+	}
+	if len(rule.Continents) > 0 && !contains(rule.Continents, res.ContinentCode()) {
+		return false
+	}
+	if len(rule.ASNs) > 0 {
+		found := false
+		for _, asn := range rule.ASNs {
+			found = found || asn == geo.AutonomousSystemNumber
+		}
+		if !found {
+			return false
+		}
+	}
+	if rule.Anonymous != nil && *rule.Anonymous != geo.IsAnonymousProxy {
+		return false
+	}
+	if rule.Satellite != nil && *rule.Satellite != geo.IsSatelliteProvider {
+		return false
+	}
+	return true
+}
+
+func contains(l []string, s string) bool {
+	for _, e := range l {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey struct{}
+
+// FromContext returns the Decision made by Middleware for the request
+func FromContext(ctx context.Context) (Decision, bool) {
+	d, ok := ctx.Value(contextKey{}).(Decision)
+	return d, ok
+}
+
+// Middleware evaluates p for each request. Blocked requests get 403
+// Forbidden, the others reach next with the Decision in their context. The
+// locale of a set-locale rule is put in the context too, see
+// webgeo.NewLocaleContext, so the language negotiation of handlers and
+// middlewares after this one ranks it first; a locale already chosen by
+// webgeo.LocalePrefix is kept, and a webgeo.Result already stored by
+// webgeo.Middleware is resolved again with it. With
+// webgeo.DryRun blocked requests are only logged and marked with
+// X-Webgeo-WouldBlock. Requests with webgeo.SkipMethods are only evaluated
+// when the location is already cached.
+func Middleware(next http.Handler, p *Policy) http.Handler {
+	return MiddlewareFor(nil, next, p)
+}
+
+// MiddlewareFor is Middleware resolving requests with g, or the package
+// settings when g is nil
+func MiddlewareFor(g *webgeo.Geolocator, next http.Handler, p *Policy) http.Handler {
+	resolve := webgeo.MiddlewareResolve
+	if g != nil {
+		resolve = g.MiddlewareResolve
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, ok := resolve(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		d := p.Evaluate(res)
+		if d.Action == Block {
+			if !webgeo.DryRun {
+				p.Audit.log(r, res, d)
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+			log.Printf("webgeo: dry run: rule %q would block %s from %s", d.Rule, r.URL.Path, res.Country)
+			w.Header().Set("X-Webgeo-WouldBlock", "true")
+		}
+		p.Audit.log(r, res, d)
+		ctx := context.WithValue(r.Context(), contextKey{}, d)
+		if _, chosen := webgeo.LocaleFromContext(ctx); d.Locale != "" && !chosen {
+			ctx = webgeo.NewLocaleContext(ctx, language.Make(d.Locale))
+			if _, stored := webgeo.ResultFromContext(ctx); stored {
+				if res, ok := resolve(r.WithContext(ctx)); ok {
+					ctx = webgeo.NewContext(ctx, res)
+				}
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,54 @@
+package policy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/seckiss/webgeo"
+	"golang.org/x/text/language"
+)
+
+func TestSetLocale(t *testing.T) {
+	p, err := Load(strings.NewReader(`
+rules:
+  - name: swiss french
+    action: set-locale
+    locale: fr-ch
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var langs []string
+	var stored webgeo.Result
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, langs = webgeo.CalcCountryAndLangs(r)
+		stored, _ = webgeo.ResultFromContext(r.Context())
+	})
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "@" // no client IP, no lookup
+		r.Header.Set("Accept-Language", "de")
+		return r
+	}
+
+	Middleware(h, p).ServeHTTP(httptest.NewRecorder(), newRequest())
+	if len(langs) != 2 || langs[0] != "fr-CH" || langs[1] != "de" {
+		t.Errorf("langs %v, want [fr-CH de]", langs)
+	}
+
+	// a Result stored before is resolved again
+	webgeo.Middleware(Middleware(h, p)).ServeHTTP(httptest.NewRecorder(), newRequest())
+	if len(stored.Langs) == 0 || stored.Langs[0] != "fr-CH" {
+		t.Errorf("stored langs %v, want fr-CH first", stored.Langs)
+	}
+
+	// a locale chosen by the URL wins
+	r := newRequest()
+	r.URL.Path = "/it/"
+	webgeo.LocalePrefix(Middleware(h, p), language.Italian).ServeHTTP(httptest.NewRecorder(), r)
+	if len(langs) == 0 || langs[0] != "it" {
+		t.Errorf("langs %v, want it first", langs)
+	}
+}
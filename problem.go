@@ -0,0 +1,76 @@
+package webgeo
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+// Problem is an RFC 7807 problem details object
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// ProblemCatalog holds the translations of Problem titles and details, keyed
+// by the English text:
+//
+//	webgeo.ProblemCatalog.SetString(language.German, "Order %d not found", "Bestellung %d nicht gefunden")
+var ProblemCatalog = catalog.NewBuilder(catalog.Fallback(language.English))
+
+// WriteProblem writes p as application/problem+json. Title and Detail are
+// translated to the language negotiated for the request among the ones in
+// ProblemCatalog. The title is plain text, the detail a format for args
+// when there are any, plain text otherwise.
+func WriteProblem(w http.ResponseWriter, r *http.Request, p Problem, args ...interface{}) error {
+	supported := []language.Tag{language.English}
+	for _, t := range ProblemCatalog.Languages() {
+		if t != language.English {
+			supported = append(supported, t)
+		}
+	}
+	tag := BestLocale(r, supported)
+	pr := message.NewPrinter(tag, message.Catalog(ProblemCatalog))
+	p.Title = translateProblem(tag, p.Title)
+	if len(args) > 0 {
+		p.Detail = pr.Sprintf(p.Detail, args...)
+	} else {
+		p.Detail = translateProblem(tag, p.Detail)
+	}
+	if p.Status == 0 {
+		p.Status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Content-Language", tag.String())
+	AddVary(w.Header(), defaultGeolocator.langVary()...)
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
+}
+
+// The translation of the ProblemCatalog message key to tag, as is without
+// formatting, key itself when there is none
+func translateProblem(tag language.Tag, key string) string {
+	if key == "" {
+		return ""
+	}
+	var text problemText
+	if err := ProblemCatalog.Context(tag, &text).Execute(key); err != nil {
+		return key
+	}
+	return text.String()
+}
+
+// renders catalog messages without formatting them
+type problemText struct {
+	strings.Builder
+}
+
+func (t *problemText) Render(s string)       { t.WriteString(s) }
+func (t *problemText) Arg(i int) interface{} { return nil }
@@ -0,0 +1,50 @@
+package webgeo
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message/catalog"
+)
+
+func TestWriteProblem(t *testing.T) {
+	saved := ProblemCatalog
+	defer func() { ProblemCatalog = saved }()
+	ProblemCatalog = catalog.NewBuilder(catalog.Fallback(language.English))
+	ProblemCatalog.SetString(language.German, "Quota 100% used", "Kontingent zu 100% verbraucht")
+	ProblemCatalog.SetString(language.German, "Order %d not found", "Bestellung %d nicht gefunden")
+
+	tests := []struct {
+		accept string
+		p      Problem
+		args   []interface{}
+		title  string
+		detail string
+	}{
+		{"de", Problem{Title: "Quota 100% used", Detail: "Order %d not found"}, []interface{}{42}, "Kontingent zu 100% verbraucht", "Bestellung 42 nicht gefunden"},
+		{"en", Problem{Title: "Quota 100% used", Detail: "Order %d not found"}, []interface{}{42}, "Quota 100% used", "Order 42 not found"},
+		{"de", Problem{Title: "Untranslated 50% off", Detail: "Only 5% left"}, nil, "Untranslated 50% off", "Only 5% left"},
+		{"de", Problem{Title: "Quota 100% used"}, nil, "Kontingent zu 100% verbraucht", ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "@" // no client IP, no lookup
+		r.Header.Set("Accept-Language", tt.accept)
+		w := httptest.NewRecorder()
+		if err := WriteProblem(w, r, tt.p, tt.args...); err != nil {
+			t.Fatal(err)
+		}
+		var got Problem
+		if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Title != tt.title || got.Detail != tt.detail || got.Status != 500 {
+			t.Errorf("%s %q: %+v, want title %q, detail %q", tt.accept, tt.p.Title, got, tt.title, tt.detail)
+		}
+		if vary := w.Header().Get("Vary"); vary != "Accept-Language" {
+			t.Errorf("Vary: %q, want Accept-Language", vary)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package webgeo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var proxyV1Prefix = []byte("PROXY ")
+var proxyV2Sig = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+// ProxyListener wraps a net.Listener behind a TCP load balancer speaking the
+// HAProxy PROXY protocol (v1 or v2). The header is stripped from the accepted
+// connections and their RemoteAddr reports the original client, so
+// CalcCountryAndLangs works without any HTTP-layer forwarding header.
+// Connections that don't start with a PROXY header are passed through as is,
+// unless RequireHeader is set.
+//
+// Anybody who can connect directly can send a header and choose the
+// RemoteAddr, so when the listener is reachable from outside the balancer
+// set Trusted, see NewTrustedProxyListener.
+type ProxyListener struct {
+	net.Listener
+	// HeaderTimeout limits how long reading the header may take, 0 means no limit
+	HeaderTimeout time.Duration
+	// Trusted are the networks of the balancers. When set, the connections
+	// of other peers are passed through as is, a header they send isn't
+	// believed and breaks the protocol spoken on top.
+	Trusted []netip.Prefix
+	// RequireHeader rejects the connections of trusted peers without a header
+	RequireHeader bool
+}
+
+func NewProxyListener(l net.Listener) *ProxyListener {
+	return &ProxyListener{Listener: l, HeaderTimeout: 10 * time.Second}
+}
+
+// NewTrustedProxyListener only believes the headers of the balancers in
+// trusted, and requires one from them
+func NewTrustedProxyListener(l net.Listener, trusted ...netip.Prefix) *ProxyListener {
+	return &ProxyListener{
+		Listener:      l,
+		HeaderTimeout: 10 * time.Second,
+		Trusted:       append([]netip.Prefix{}, trusted...),
+		RequireHeader: true,
+	}
+}
+
+func (l *ProxyListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	if l.Trusted != nil && !isTrustedProxy(remoteIP(c.RemoteAddr().String()), l.Trusted) {
+		return c, nil
+	}
+	return &proxyConn{Conn: c, r: bufio.NewReader(c), timeout: l.HeaderTimeout, require: l.RequireHeader}, nil
+}
+
+// The header is parsed lazily on first Read or RemoteAddr, so a slow client
+// can't block the accept loop.
+type proxyConn struct {
+	net.Conn
+	r       *bufio.Reader
+	timeout time.Duration
+	require bool
+	once    sync.Once
+	remote  net.Addr
+	err     error
+
+	// the read deadline set by the user of the connection, e.g.
+	// ReadHeaderTimeout of http.Server, restored after the header
+	mu       sync.Mutex
+	deadline time.Time
+}
+
+func (c *proxyConn) init() {
+	c.once.Do(func() {
+		c.mu.Lock()
+		deadline := c.deadline
+		c.mu.Unlock()
+		if t := time.Now().Add(c.timeout); c.timeout > 0 && (deadline.IsZero() || t.Before(deadline)) {
+			c.Conn.SetReadDeadline(t)
+			defer func() {
+				c.mu.Lock()
+				c.Conn.SetReadDeadline(c.deadline)
+				c.mu.Unlock()
+			}()
+		}
+		var present bool
+		present, c.remote, c.err = readProxyHeader(c.r)
+		if c.err == nil && !present && c.require {
+			c.err = fmt.Errorf("Missing PROXY header from %s", c.Conn.RemoteAddr())
+		}
+	})
+}
+
+func (c *proxyConn) SetDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deadline = t
+	return c.Conn.SetDeadline(t)
+}
+
+func (c *proxyConn) SetReadDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deadline = t
+	return c.Conn.SetReadDeadline(t)
+}
+
+func (c *proxyConn) Read(b []byte) (int, error) {
+	c.init()
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.r.Read(b)
+}
+
+func (c *proxyConn) RemoteAddr() net.Addr {
+	c.init()
+	if c.remote != nil {
+		return c.remote
+	}
+	return c.Conn.RemoteAddr()
+}
+
+// Consume the PROXY header if present and return the announced source
+// address. A nil address with nil error means there was no usable address
+// (no header, LOCAL command, UNKNOWN or unix family).
+func readProxyHeader(r *bufio.Reader) (present bool, addr net.Addr, err error) {
+	b, err := r.Peek(len(proxyV2Sig))
+	if bytes.Equal(b, proxyV2Sig) {
+		addr, err = readProxyV2(r)
+		return true, addr, err
+	}
+	if bytes.HasPrefix(b, proxyV1Prefix) {
+		addr, err = readProxyV1(r)
+		return true, addr, err
+	}
+	if err != nil && err != io.EOF {
+		return false, nil, err
+	}
+	return false, nil, nil
+}
+
+// PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\n
+func readProxyV1(r *bufio.Reader) (net.Addr, error) {
+	line, err := r.ReadSlice('\n')
+	if err != nil {
+		return nil, fmt.Errorf("Could not read PROXY v1 header: %v", err)
+	}
+	// 107 bytes is the maximum length allowed by the spec
+	if len(line) > 107 || !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, fmt.Errorf("Invalid PROXY v1 header")
+	}
+	fields := strings.Fields(string(line))
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return nil, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, fmt.Errorf("Invalid PROXY v1 header: %q", line)
+	}
+	ip := net.ParseIP(fields[2])
+	port, err := strconv.Atoi(fields[4])
+	if ip == nil || err != nil || port < 0 || port > 65535 {
+		return nil, fmt.Errorf("Invalid PROXY v1 header: %q", line)
+	}
+	return &net.TCPAddr{IP: ip, Port: port}, nil
+}
+
+func readProxyV2(r *bufio.Reader) (net.Addr, error) {
+	var hdr [16]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, fmt.Errorf("Could not read PROXY v2 header: %v", err)
+	}
+	if hdr[12]>>4 != 2 {
+		return nil, fmt.Errorf("Unsupported PROXY protocol version %d", hdr[12]>>4)
+	}
+	body := make([]byte, binary.BigEndian.Uint16(hdr[14:16]))
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, fmt.Errorf("Could not read PROXY v2 addresses: %v", err)
+	}
+	// LOCAL command: health checks from the balancer itself
+	if hdr[12]&0x0f == 0 {
+		return nil, nil
+	}
+	switch hdr[13] >> 4 {
+	case 1: // AF_INET
+		if len(body) < 12 {
+			return nil, fmt.Errorf("Short PROXY v2 IPv4 address block")
+		}
+		return &net.TCPAddr{IP: net.IP(body[0:4]), Port: int(binary.BigEndian.Uint16(body[8:10]))}, nil
+	case 2: // AF_INET6
+		if len(body) < 36 {
+			return nil, fmt.Errorf("Short PROXY v2 IPv6 address block")
+		}
+		return &net.TCPAddr{IP: net.IP(body[0:16]), Port: int(binary.BigEndian.Uint16(body[32:34]))}, nil
+	}
+	return nil, nil
+}
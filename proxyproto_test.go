@@ -0,0 +1,139 @@
+package webgeo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+func proxyV2Header(cmd, family byte, addrs []byte) string {
+	hdr := append([]byte{}, proxyV2Sig...)
+	hdr = append(hdr, 0x20|cmd, family<<4|1, 0, 0)
+	binary.BigEndian.PutUint16(hdr[14:], uint16(len(addrs)))
+	return string(append(hdr, addrs...))
+}
+
+func TestReadProxyHeader(t *testing.T) {
+	v4 := []byte{192, 0, 2, 1, 198, 51, 100, 1, 0xdc, 0x04, 0x01, 0xbb}
+	v6 := make([]byte, 36)
+	copy(v6, netip.MustParseAddr("2001:db8::1").AsSlice())
+	v6[32], v6[33] = 0x1f, 0x90
+	tests := []struct {
+		name, in string
+		present  bool
+		addr     string // "" for none
+		err      bool
+		rest     string
+	}{
+		{"v1 tcp4", "PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\nGET /", true, "192.0.2.1:56324", false, "GET /"},
+		{"v1 tcp6", "PROXY TCP6 2001:db8::1 2001:db8::2 8080 443\r\nGET /", true, "[2001:db8::1]:8080", false, "GET /"},
+		{"v1 unknown", "PROXY UNKNOWN\r\nGET /", true, "", false, "GET /"},
+		{"v1 bad ip", "PROXY TCP4 300.0.2.1 198.51.100.1 56324 443\r\n", true, "", true, ""},
+		{"v1 bad port", "PROXY TCP4 192.0.2.1 198.51.100.1 99999 443\r\n", true, "", true, ""},
+		{"v1 no crlf", "PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\n", true, "", true, ""},
+		{"v1 too long", "PROXY TCP4 " + strings.Repeat("1", 100) + "\r\n", true, "", true, ""},
+		{"v2 ipv4", proxyV2Header(1, 1, v4) + "GET /", true, "192.0.2.1:56324", false, "GET /"},
+		{"v2 ipv6", proxyV2Header(1, 2, v6) + "GET /", true, "[2001:db8::1]:8080", false, "GET /"},
+		{"v2 local", proxyV2Header(0, 1, v4) + "GET /", true, "", false, "GET /"},
+		{"v2 short", proxyV2Header(1, 1, v4[:8]), true, "", true, ""},
+		{"v2 truncated", proxyV2Header(1, 1, v4)[:20], true, "", true, ""},
+		{"none", "GET / HTTP/1.1\r\nHost: x\r\n\r\n", false, "", false, "GET / HTTP/1.1\r\nHost: x\r\n\r\n"},
+		{"short none", "GET /", false, "", false, "GET /"},
+	}
+	for _, tt := range tests {
+		r := bufio.NewReader(strings.NewReader(tt.in))
+		present, addr, err := readProxyHeader(r)
+		if present != tt.present || (err != nil) != tt.err {
+			t.Errorf("%s: present %v, err %v", tt.name, present, err)
+			continue
+		}
+		if tt.err {
+			continue
+		}
+		got := ""
+		if addr != nil {
+			got = addr.String()
+		}
+		if got != tt.addr {
+			t.Errorf("%s: addr %q, want %q", tt.name, got, tt.addr)
+		}
+		if rest, _ := io.ReadAll(r); string(rest) != tt.rest {
+			t.Errorf("%s: rest %q, want %q", tt.name, rest, tt.rest)
+		}
+	}
+}
+
+// dial l, write out and return the accepted connection
+func acceptWith(t *testing.T, l net.Listener, out string) net.Conn {
+	t.Helper()
+	client, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	if _, err := io.WriteString(client, out); err != nil {
+		t.Fatal(err)
+	}
+	c, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestProxyListenerTrusted(t *testing.T) {
+	inner, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer inner.Close()
+	header := "PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\n"
+
+	l := NewTrustedProxyListener(inner, netip.MustParsePrefix("127.0.0.0/8"))
+	l.HeaderTimeout = 100 * time.Millisecond
+	c := acceptWith(t, l, header+"ping")
+	if got := remoteIP(c.RemoteAddr().String()); got != "192.0.2.1" {
+		t.Errorf("trusted peer: RemoteAddr %s", got)
+	}
+	c = acceptWith(t, l, "ping")
+	if _, err := c.Read(make([]byte, 4)); err == nil {
+		t.Errorf("trusted peer without header: no error")
+	}
+
+	l = NewTrustedProxyListener(inner, netip.MustParsePrefix("10.0.0.0/8"))
+	c = acceptWith(t, l, header)
+	if got := remoteIP(c.RemoteAddr().String()); got != "127.0.0.1" {
+		t.Errorf("untrusted peer: RemoteAddr %s", got)
+	}
+	b := make([]byte, len(header))
+	if _, err := io.ReadFull(c, b); err != nil || string(b) != header {
+		t.Errorf("untrusted peer: read %q, %v", b, err)
+	}
+}
+
+type deadlineConn struct {
+	net.Conn
+	deadline time.Time
+}
+
+func (c *deadlineConn) SetReadDeadline(t time.Time) error {
+	c.deadline = t
+	return nil
+}
+
+func TestProxyConnKeepsDeadline(t *testing.T) {
+	inner := &deadlineConn{Conn: &net.TCPConn{}}
+	c := &proxyConn{Conn: inner, r: bufio.NewReader(strings.NewReader("PROXY UNKNOWN\r\n")), timeout: time.Minute}
+	deadline := time.Now().Add(time.Hour)
+	c.SetReadDeadline(deadline)
+	c.init()
+	if !inner.deadline.Equal(deadline) {
+		t.Errorf("deadline %v after the header, want %v", inner.deadline, deadline)
+	}
+}
@@ -0,0 +1,174 @@
+package webgeo
+
+import (
+	"container/list"
+	"log"
+	"math"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitClients bounds the clients RateLimit keeps a bucket for. The one
+// seen least recently is forgotten to make room and starts over with a full
+// bucket, so size it well above the clients active within a Per.
+var RateLimitClients = 100000
+
+// Rate allows Requests per Per on average, in bursts of up to Burst
+// requests, Requests when 0. The zero Rate is unlimited, see BlockCountries
+// to turn a country away altogether.
+type Rate struct {
+	Requests int
+	Per      time.Duration
+	Burst    int
+}
+
+func (rate Rate) unlimited() bool {
+	return rate.Requests <= 0 || rate.Per <= 0
+}
+
+func (rate Rate) burst() float64 {
+	if rate.Burst > 0 {
+		return float64(rate.Burst)
+	}
+	return float64(rate.Requests)
+}
+
+// tokens per nanosecond
+func (rate Rate) refill() float64 {
+	return float64(rate.Requests) / float64(rate.Per)
+}
+
+// RateLimit limits the requests of each client to the rate of its
+// country or continent in rates, keyed as in ForCountry, and to def when it
+// has none or the location is unknown. Requests over the limit get 429 Too
+// Many Requests with Retry-After. Requests with SkipMethods only get the
+// rate of their country when the location is already cached. A client is an
+// IPv4 address or an IPv6 /64, the network usually given to one customer.
+//
+//	webgeo.RateLimit(mux, map[string]webgeo.Rate{
+//		"US": {}, // unlimited
+//		"continent:AS": {Requests: 10, Per: time.Minute},
+//	}, webgeo.Rate{Requests: 100, Per: time.Minute})
+func RateLimit(next http.Handler, rates map[string]Rate, def Rate) http.Handler {
+	return defaultGeolocator.RateLimit(next, rates, def)
+}
+
+func (g *Geolocator) RateLimit(next http.Handler, rates map[string]Rate, def Rate) http.Handler {
+	l := newRateLimiter(RateLimitClients)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, ok := g.MiddlewareResolve(r)
+		cc := "ZZ"
+		if ok {
+			cc = res.Country
+		}
+		rate := ForCountry(rates, cc, res.ContinentCode(), def)
+		ip := rateKey(g.ClientIP(r))
+		if rate.unlimited() || ip == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		wait := l.take(ip, rate, time.Now())
+		if wait == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if DryRun {
+			log.Printf("webgeo: dry run: would limit %s from %s", r.URL.Path, cc)
+			w.Header().Set("X-Webgeo-WouldLimit", "true")
+			next.ServeHTTP(w, r)
+			return
+		}
+		varyGeo(w.Header())
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+	})
+}
+
+// how often idle clients are forgotten
+const rateSweepInterval = time.Minute
+
+// the client of ip for RateLimit, its /64 for IPv6
+func rateKey(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || addr.Unmap().Is4() {
+		return ip
+	}
+	prefix, _ := addr.Prefix(64)
+	return prefix.String()
+}
+
+// Token buckets by client, least recently seen first out
+type rateLimiter struct {
+	mutex   sync.Mutex
+	size    int // max buckets, 0 is unbounded
+	buckets map[string]*list.Element
+	lru     *list.List // of *bucket, most recently seen first
+	swept   time.Time
+}
+
+type bucket struct {
+	ip     string
+	rate   Rate
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(size int) *rateLimiter {
+	return &rateLimiter{size: size, buckets: make(map[string]*list.Element), lru: list.New()}
+}
+
+// Take a token from the bucket of ip, returns how long to wait for one when
+// it is empty
+func (l *rateLimiter) take(ip string, rate Rate, now time.Time) time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if now.Sub(l.swept) > rateSweepInterval {
+		l.sweep(now)
+	}
+	var b *bucket
+	if el, pres := l.buckets[ip]; pres {
+		b = el.Value.(*bucket)
+		l.lru.MoveToFront(el)
+	} else {
+		b = &bucket{ip: ip, rate: rate, tokens: rate.burst(), last: now}
+		l.buckets[ip] = l.lru.PushFront(b)
+		for l.size > 0 && l.lru.Len() > l.size {
+			l.remove(l.lru.Back())
+		}
+	}
+	if b.rate != rate {
+		// a client that moved
+		b.rate, b.tokens, b.last = rate, rate.burst(), now
+	}
+	b.fill(now)
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / rate.refill())
+	}
+	b.tokens--
+	return 0
+}
+
+func (b *bucket) fill(now time.Time) {
+	b.tokens = math.Min(b.rate.burst(), b.tokens+float64(now.Sub(b.last))*b.rate.refill())
+	b.last = now
+}
+
+// Forget the clients whose bucket is full again, they start over with a
+// full one anyway
+func (l *rateLimiter) sweep(now time.Time) {
+	for _, el := range l.buckets {
+		b := el.Value.(*bucket)
+		if b.fill(now); b.tokens >= b.rate.burst() {
+			l.remove(el)
+		}
+	}
+	l.swept = now
+}
+
+func (l *rateLimiter) remove(el *list.Element) {
+	l.lru.Remove(el)
+	delete(l.buckets, el.Value.(*bucket).ip)
+}
@@ -0,0 +1,62 @@
+package webgeo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateKey(t *testing.T) {
+	tests := map[string]string{
+		"192.0.2.1":            "192.0.2.1",
+		"::ffff:192.0.2.1":     "::ffff:192.0.2.1",
+		"2001:db8:1:2:3:4:5:6": "2001:db8:1:2::/64",
+		"2001:db8:1:2:ffff::1": "2001:db8:1:2::/64",
+		"2001:db8:1:3::1":      "2001:db8:1:3::/64",
+		"":                     "",
+	}
+	for ip, want := range tests {
+		if got := rateKey(ip); got != want {
+			t.Errorf("rateKey(%q) = %q, want %q", ip, got, want)
+		}
+	}
+}
+
+func TestRateLimiterBounded(t *testing.T) {
+	l := newRateLimiter(10)
+	rate := Rate{Requests: 1, Per: time.Hour}
+	now := time.Now()
+	for i := 0; i < 1000; i++ {
+		l.take("10.0."+strconv.Itoa(i/256)+"."+strconv.Itoa(i%256), rate, now)
+	}
+	if len(l.buckets) != 10 || l.lru.Len() != 10 {
+		t.Errorf("%d buckets, %d in the LRU, want 10", len(l.buckets), l.lru.Len())
+	}
+	if l.take("10.0.3.231", rate, now) == 0 {
+		t.Errorf("recent client not limited")
+	}
+	if l.take("10.0.0.0", rate, now) != 0 {
+		t.Errorf("forgotten client limited")
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	g := New(WithDBPath(brokenDB(t)))
+	h := g.RateLimit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), nil, Rate{Requests: 2, Per: time.Hour})
+	codes := []int{}
+	for _, addr := range []string{"[2001:db8::1]:1", "[2001:db8::2]:1", "[2001:db8::3]:1", "[2001:db8:0:1::1]:1", "192.0.2.1:1"} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = addr
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		codes = append(codes, w.Code)
+	}
+	want := []int{200, 200, 429, 200, 200}
+	for i := range want {
+		if codes[i] != want[i] {
+			t.Fatalf("codes %v, want %v", codes, want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package webgeo
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RedirectOptOutCookie keeps a visitor from being redirected by
+// RedirectCountries when set to any value, e.g. by a "stay on example.com"
+// link.
+var RedirectOptOutCookie = "webgeo_stay"
+
+// RedirectCountries redirects GET requests 302 Found to the target of the
+// visitor's country or continent, keyed as in ForCountry. A target is a host
+// ("example.de"), a path prefix ("/fr/") or both ("https://example.ch/fr/");
+// the path and query of the request are kept. Requests already on any of the
+// targets are passed to next, so links between country sites work and
+// redirects can't loop, as are visitors with the RedirectOptOutCookie.
+func RedirectCountries(next http.Handler, targets map[string]string) http.Handler {
+	return defaultGeolocator.RedirectCountries(next, targets)
+}
+
+func (g *Geolocator) RedirectCountries(next http.Handler, targets map[string]string) http.Handler {
+	parsed := make(map[string]*url.URL)
+	for code, t := range targets {
+		u, err := parseRedirectTarget(t)
+		if err != nil {
+			log.Printf("webgeo: invalid redirect target %q for %s: %v", t, code, err)
+			continue
+		}
+		parsed[code] = u
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || onRedirectTarget(r, parsed) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		AddVary(w.Header(), "Cookie", GeoVary)
+		if c, err := r.Cookie(RedirectOptOutCookie); err == nil && c.Value != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		res, ok := g.MiddlewareResolve(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		target := ForCountry(parsed, res.Country, res.ContinentCode(), nil)
+		if target == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		dest := redirectURL(r, target)
+		varyGeo(w.Header())
+		if DryRun {
+			log.Printf("webgeo: dry run: would redirect %s from %s to %s", r.URL.Path, res.Country, dest)
+			w.Header().Set("X-Webgeo-WouldRedirect", dest)
+			next.ServeHTTP(w, r)
+			return
+		}
+		http.Redirect(w, r, dest, http.StatusFound)
+	})
+}
+
+// "example.de" is a host, "/fr/" a path
+func parseRedirectTarget(t string) (*url.URL, error) {
+	if !strings.Contains(t, "/") {
+		t = "//" + t
+	}
+	u, err := url.Parse(t)
+	if err != nil {
+		return nil, err
+	}
+	if u.Path != "" && !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u, nil
+}
+
+// whether r is on the host and under the path of one of the targets
+func onRedirectTarget(r *http.Request, targets map[string]*url.URL) bool {
+	for _, t := range targets {
+		if t.Host != "" && !strings.EqualFold(t.Host, r.Host) {
+			continue
+		}
+		if strings.HasPrefix(r.URL.Path+"/", t.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+func redirectURL(r *http.Request, target *url.URL) string {
+	u := *r.URL
+	u.Scheme, u.Host = "", ""
+	if target.Host != "" {
+		u.Scheme, u.Host = target.Scheme, target.Host
+		if u.Scheme == "" {
+			u.Scheme = "http"
+			if r.TLS != nil {
+				u.Scheme = "https"
+			}
+		}
+	}
+	if target.Path != "" {
+		u.Path = target.Path + strings.TrimPrefix(r.URL.Path, "/")
+		u.RawPath = ""
+	}
+	return u.String()
+}
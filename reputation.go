@@ -0,0 +1,37 @@
+package webgeo
+
+// ReputationInfo is what a threat feed knows about an IP
+type ReputationInfo struct {
+	// 0 (clean) to 100 (known bad), the scale is up to the provider
+	Score      int      `json:"score"`
+	Categories []string `json:"categories,omitempty"` // e.g. "tor", "botnet"
+}
+
+// Reputation is consulted by Resolve next to geolocation, so a threat feed
+// can be attached without another middleware layer. Check returns nil when
+// nothing is known about the IP. It is called on every Resolve, implementations
+// should cache as they see fit.
+type Reputation interface {
+	Check(ip string) (*ReputationInfo, error)
+}
+
+// ReputationProvider is the Reputation used by Resolve, by default a no-op
+var ReputationProvider Reputation = NoReputation{}
+
+type NoReputation struct{}
+
+func (NoReputation) Check(ip string) (*ReputationInfo, error) {
+	return nil, nil
+}
+
+func checkReputation(ip string) *ReputationInfo {
+	if ip == "" || ReputationProvider == nil {
+		return nil
+	}
+	info, err := ReputationProvider.Check(ip)
+	if err != nil {
+		recordError(err)
+		return nil
+	}
+	return info
+}
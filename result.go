@@ -0,0 +1,84 @@
+package webgeo
+
+import "net/http"
+
+// SkipMethods are the request methods middlewares don't resolve the location
+// for: a CORS preflight storm would otherwise cost a full lookup per origin
+// check. They still get results that are already in the cache.
+var SkipMethods = map[string]bool{
+	http.MethodOptions: true,
+	http.MethodHead:    true,
+}
+
+// Result is what was resolved for a request
+type Result struct {
+	Country string   `json:"country"`
+	Langs   []string `json:"langs"`
+	// where each of Langs comes from
+	Sources map[string]Source `json:"sources,omitempty"`
+	// nil when the lookup failed, Cc is empty when the IP is not in the database
+	Geo *GeoRecord `json:"geo,omitempty"`
+	// browser and country languages have nothing in common, see LangConflictPolicy
+	Conflict bool `json:"conflict,omitempty"`
+	// suggested display currency, see CurrencyFor
+	Currency     string `json:"currency,omitempty"`
+	CurrencyName string `json:"currency_name,omitempty"`
+	// prefix for phone number inputs, see CallingCodeFor
+	CallingCode string `json:"calling_code,omitempty"`
+	// from ReputationProvider, nil when nothing is known
+	Reputation *ReputationInfo `json:"reputation,omitempty"`
+}
+
+// Resolve is CalcCountryAndLangs packed in a Result
+func Resolve(r *http.Request) Result {
+	return defaultGeolocator.Resolve(r)
+}
+
+func (g *Geolocator) Resolve(r *http.Request) Result {
+	res, _ := g.resolve(r)
+	return res
+}
+
+// Resolve with the error of CalcCountryAndLangsE
+func (g *Geolocator) resolve(r *http.Request) (Result, error) {
+	res, err := g.calcCountryAndLangs(r)
+	res.Currency, res.CurrencyName = CurrencyFor(res.Country)
+	res.CallingCode = CallingCodeFor(res.Country)
+	res.Reputation = checkReputation(g.ClientIP(r))
+	return res, err
+}
+
+// ResolveCached is Resolve without a database lookup. ok is false when the
+// client IP is not in the cache.
+func ResolveCached(r *http.Request) (res Result, ok bool) {
+	return defaultGeolocator.ResolveCached(r)
+}
+
+func (g *Geolocator) ResolveCached(r *http.Request) (res Result, ok bool) {
+	if _, ok = g.cache.Get(g.cacheKey(g.ClientIP(r))); !ok {
+		return Result{}, false
+	}
+	return g.Resolve(r), true
+}
+
+// MiddlewareResolve is Resolve for middlewares: requests with SkipMethods
+// only get cached results, ok is false when there is none.
+func MiddlewareResolve(r *http.Request) (res Result, ok bool) {
+	return defaultGeolocator.MiddlewareResolve(r)
+}
+
+func (g *Geolocator) MiddlewareResolve(r *http.Request) (res Result, ok bool) {
+	if SkipMethods[r.Method] {
+		return g.ResolveCached(r)
+	}
+	return g.Resolve(r), true
+}
+
+// ContinentCode of the visitor, from the database record when available,
+// otherwise from the country table
+func (res Result) ContinentCode() string {
+	if res.Geo != nil && res.Geo.ContinentCode != "" {
+		return res.Geo.ContinentCode
+	}
+	return continentOf(res.Country)
+}
@@ -0,0 +1,51 @@
+package webgeo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Market is a country-specific section of the site served under its own
+// path prefix, e.g. Market{"/de/", []string{"DE", "AT", "CH"}, true}
+type Market struct {
+	Prefix    string
+	Countries []string
+	Launched  bool
+}
+
+// Markets is the locale routing scheme. Everything that needs to know which
+// market sections exist (robots.txt, redirects) reads it from here.
+var Markets = []Market{}
+
+// Build robots.txt content from the markets. Sections of markets that are
+// not launched yet are disallowed. crawlDelay <= 0 omits Crawl-delay.
+func RobotsTxt(markets []Market, crawlDelay int) string {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	if crawlDelay > 0 {
+		fmt.Fprintf(&b, "Crawl-delay: %d\n", crawlDelay)
+	}
+	disallowed := 0
+	for _, m := range markets {
+		if m.Launched || m.Prefix == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "# market %s not launched yet\n", strings.Join(m.Countries, ","))
+		fmt.Fprintf(&b, "Disallow: %s\n", m.Prefix)
+		disallowed++
+	}
+	if disallowed == 0 {
+		// empty Disallow means everything is allowed
+		b.WriteString("Disallow:\n")
+	}
+	return b.String()
+}
+
+// RobotsHandler serves /robots.txt generated from the current Markets
+func RobotsHandler(crawlDelay int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, RobotsTxt(Markets, crawlDelay))
+	})
+}
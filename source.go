@@ -0,0 +1,18 @@
+package webgeo
+
+// Source tells where a suggested language comes from, e.g. to show
+// "detected from your location" next to it
+type Source string
+
+const (
+	// the Accept-Language header
+	SourceBrowser Source = "browser"
+	// the languages of the visitor's country
+	SourceGeo Source = "geo"
+	// an explicit choice of the visitor
+	SourceOverride Source = "override"
+	// the language set with WithFallbackLang
+	SourceFallback Source = "fallback"
+	// negotiated on an earlier request, see WithLocaleCookie
+	SourceStored Source = "stored"
+)
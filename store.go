@@ -0,0 +1,35 @@
+package webgeo
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Store persists small pieces of state across restarts
+type Store interface {
+	// Load returns nil, nil when key was never saved
+	Load(key string) ([]byte, error)
+	Save(key string, value []byte) error
+}
+
+// FileStore keeps each key in a file in Dir
+type FileStore struct {
+	Dir string
+}
+
+func (s FileStore) Load(key string) ([]byte, error) {
+	b, err := os.ReadFile(filepath.Join(s.Dir, key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return b, err
+}
+
+func (s FileStore) Save(key string, value []byte) error {
+	path := filepath.Join(s.Dir, key)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, value, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
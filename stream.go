@@ -0,0 +1,98 @@
+package webgeo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/netip"
+)
+
+// lines looked up together by EnrichStream, bounds its memory
+const enrichChunk = 1000
+
+// max length of an EnrichStream input line
+const maxLineSize = 1 << 20
+
+// EnrichStream reads newline delimited IPs, or JSON objects with an "ip"
+// field, and writes one JSON object per line to w with the "geo" record
+// (null when unknown) and the "geo_langs" of the country added. Plain IPs
+// become {"ip": ...} objects. Lines are processed in chunks with LookupBatch,
+// so memory stays bounded for any input size. Blank lines are skipped.
+func EnrichStream(r io.Reader, w io.Writer) error {
+	return defaultGeolocator.EnrichStream(r, w)
+}
+
+func (g *Geolocator) EnrichStream(r io.Reader, w io.Writer) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), maxLineSize)
+	bw := bufio.NewWriter(w)
+	objs := make([]map[string]json.RawMessage, 0, enrichChunk)
+	addrs := make([]netip.Addr, 0, enrichChunk)
+	n := 0
+	for sc.Scan() {
+		n++
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		obj, addr, err := parseEnrichLine(line)
+		if err != nil {
+			return fmt.Errorf("Line %d: %v", n, err)
+		}
+		objs = append(objs, obj)
+		addrs = append(addrs, addr)
+		if len(objs) == enrichChunk {
+			if err := g.writeEnriched(bw, objs, addrs); err != nil {
+				return err
+			}
+			objs, addrs = objs[:0], addrs[:0]
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	if err := g.writeEnriched(bw, objs, addrs); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// an invalid address is returned for unparsable IPs, they get no geo
+func parseEnrichLine(line []byte) (map[string]json.RawMessage, netip.Addr, error) {
+	obj := map[string]json.RawMessage{}
+	var ipS string
+	if line[0] == '{' {
+		if err := json.Unmarshal(line, &obj); err != nil {
+			return nil, netip.Addr{}, err
+		}
+		if raw, pres := obj["ip"]; pres {
+			if err := json.Unmarshal(raw, &ipS); err != nil {
+				return nil, netip.Addr{}, fmt.Errorf("ip is not a string")
+			}
+		}
+	} else {
+		ipS = string(line)
+		obj["ip"], _ = json.Marshal(ipS)
+	}
+	addr, _ := netip.ParseAddr(remoteIP(ipS))
+	return obj, addr, nil
+}
+
+func (g *Geolocator) writeEnriched(w io.Writer, objs []map[string]json.RawMessage, addrs []netip.Addr) error {
+	enc := json.NewEncoder(w)
+	for i, res := range g.LookupBatch(addrs) {
+		var err error
+		if objs[i]["geo"], err = json.Marshal(res.Geo); err != nil {
+			return err
+		}
+		if objs[i]["geo_langs"], err = json.Marshal(res.Langs); err != nil {
+			return err
+		}
+		if err := enc.Encode(objs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package webgeo
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	geoip2 "github.com/oschwald/geoip2-golang"
+)
+
+// DBDownloadURL is where UpdateDB gets an edition, formatted with the
+// edition and the suffix ("tar.gz" or "tar.gz.sha256"). The license key is
+// added as the license_key parameter.
+var DBDownloadURL = "https://download.maxmind.com/app/geoip_download?edition_id=%s&suffix=%s"
+
+// UpdateDB downloads the edition, e.g. "GeoLite2-City", with the MaxMind
+// licenseKey, checks it against its published SHA-256 checksum and installs
+// its database at path atomically, so readers see either the old or the new
+// file. The checksum is kept in path.sha256 and nothing is downloaded when
+// it didn't change; updated tells. Open Geolocators keep the database they
+// read, Close them to switch.
+func UpdateDB(ctx context.Context, licenseKey, edition, path string) (updated bool, err error) {
+	if licenseKey == "" {
+		return false, errors.New("Missing license key")
+	}
+	sum, err := downloadChecksum(ctx, licenseKey, edition)
+	if err != nil {
+		return false, err
+	}
+	if old, err := os.ReadFile(path + ".sha256"); err == nil && strings.TrimSpace(string(old)) == sum {
+		if _, err := os.Stat(path); err == nil {
+			return false, nil
+		}
+	}
+
+	dir := filepath.Dir(path)
+	archive, err := os.CreateTemp(dir, ".webgeo-download-*")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+	if err := downloadEdition(ctx, licenseKey, edition, "tar.gz", archive); err != nil {
+		return false, err
+	}
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, archive); err != nil {
+		return false, err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != sum {
+		return false, fmt.Errorf("Checksum mismatch for %s: got %s, want %s", edition, got, sum)
+	}
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".webgeo-"+edition+"-*.mmdb")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+	if err := extractMMDB(archive, edition+".mmdb", tmp); err != nil {
+		tmp.Close()
+		return false, err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return false, err
+	}
+	// never install a file the library can't read
+	db, err := geoip2.Open(tmp.Name())
+	if err != nil {
+		return false, fmt.Errorf("Invalid database in %s: %v", edition, err)
+	}
+	db.Close()
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return false, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(path+".sha256", []byte(sum+"\n"), 0644)
+}
+
+// The SHA-256 of the archive of edition, "<hex>  <file name>" on the wire
+func downloadChecksum(ctx context.Context, licenseKey, edition string) (string, error) {
+	var b bytes.Buffer
+	if err := downloadEdition(ctx, licenseKey, edition, "tar.gz.sha256", &b); err != nil {
+		return "", err
+	}
+	fields := strings.Fields(b.String())
+	if len(fields) == 0 || len(fields[0]) != sha256.Size*2 {
+		return "", fmt.Errorf("Invalid checksum for %s", edition)
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+func downloadEdition(ctx context.Context, licenseKey, edition, suffix string, w io.Writer) error {
+	u, err := url.Parse(fmt.Sprintf(DBDownloadURL, url.QueryEscape(edition), url.QueryEscape(suffix)))
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	q.Set("license_key", licenseKey)
+	u.RawQuery = q.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// the URL of a *url.Error carries the license key
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("Could not download %s.%s: %v", edition, suffix, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Could not download %s.%s: %s", edition, suffix, resp.Status)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("Could not download %s.%s: %v", edition, suffix, err)
+	}
+	return nil
+}
+
+// Copy the file called name from the tar.gz r to w, the archives of MaxMind
+// keep it in a dated directory
+func extractMMDB(r io.Reader, name string, w io.Writer) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return fmt.Errorf("No %s in the archive", name)
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag == tar.TypeReg && path.Base(hdr.Name) == name {
+			_, err = io.Copy(w, tr)
+			return err
+		}
+	}
+}
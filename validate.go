@@ -0,0 +1,59 @@
+package webgeo
+
+import "fmt"
+
+// StrictCodes validates the codes coming from the database against the
+// embedded tables. Unknown codes, e.g. retired ones in an old database, are
+// cleared and reported in GeoRecord.Warnings instead of reaching switch
+// statements downstream. Subdivisions are only checked for the ISO 3166-2
+// format as there is no embedded subdivision table.
+var StrictCodes = false
+
+var continentCodes = map[string]bool{
+	"AF": true, "AN": true, "AS": true, "EU": true, "NA": true, "OC": true, "SA": true,
+}
+
+func validateCodes(geo *GeoRecord) {
+	checkCountry := func(field string, cc *string) {
+		if _, pres := countryMap[*cc]; *cc != "" && !pres {
+			geo.warn("Unknown %s code %q", field, *cc)
+			*cc = ""
+		}
+	}
+	checkCountry("country", &geo.Cc)
+	checkCountry("registered country", &geo.RegisteredCountry)
+	checkCountry("represented country", &geo.RepresentedCountry)
+	if geo.ContinentCode != "" && !continentCodes[geo.ContinentCode] {
+		geo.warn("Unknown continent code %q", geo.ContinentCode)
+		geo.ContinentCode = ""
+	}
+	for i := range geo.Subdivisions {
+		s := &geo.Subdivisions[i]
+		if s.IsoCode != "" && !validSubdivisionCode(s.IsoCode) {
+			geo.warn("Invalid subdivision code %q", s.IsoCode)
+			s.IsoCode = ""
+		}
+	}
+	if n := len(geo.Subdivisions); n > 0 {
+		geo.MostSpecificSubdivision = geo.Subdivisions[n-1]
+	}
+}
+
+// 1 to 3 upper case letters or digits
+func validSubdivisionCode(code string) bool {
+	if len(code) < 1 || len(code) > 3 {
+		return false
+	}
+	for _, c := range code {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func (geo *GeoRecord) warn(format string, a ...interface{}) {
+	w := fmt.Sprintf(format, a...)
+	geo.Warnings = append(geo.Warnings, w)
+	recordError(fmt.Errorf("%s for %s", w, geo.Ip))
+}
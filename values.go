@@ -0,0 +1,72 @@
+package webgeo
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// query parameters used by Result.Values
+const (
+	valuesCountry  = "geo_country"
+	valuesLang     = "geo_lang"
+	valuesSource   = "geo_source"
+	valuesConflict = "geo_conflict"
+)
+
+// Values encodes the locale part of the result as query parameters
+// (geo_country, geo_lang and geo_source repeated in order, geo_conflict)
+// to carry it through redirects and OAuth flows. Merge them into the
+// redirect URL's query.
+func (res Result) Values() url.Values {
+	v := url.Values{}
+	v.Set(valuesCountry, res.Country)
+	sources := []string{}
+	for _, l := range res.Langs {
+		v.Add(valuesLang, l)
+		if src, pres := res.Sources[l]; pres {
+			sources = append(sources, string(src))
+		}
+	}
+	if len(sources) == len(res.Langs) {
+		v[valuesSource] = sources
+	}
+	if res.Conflict {
+		v.Set(valuesConflict, "1")
+	}
+	return v
+}
+
+// ParseValues reconstructs a Result from Result.Values without a lookup.
+// Geo and Reputation are not carried, the country derived fields are filled
+// again. The values come from the client: they are validated but can be
+// forged, so never use them for blocking decisions.
+func ParseValues(v url.Values) (Result, error) {
+	country := strings.ToUpper(v.Get(valuesCountry))
+	if _, pres := countryMap[country]; !pres && country != "ZZ" {
+		return Result{}, fmt.Errorf("Invalid country %q", v.Get(valuesCountry))
+	}
+	res := Result{Country: country, Langs: []string{}, Conflict: v.Get(valuesConflict) == "1"}
+	for _, l := range v[valuesLang] {
+		t, err := parseLang(l)
+		if err != nil {
+			return Result{}, fmt.Errorf("Invalid language %q", l)
+		}
+		res.Langs = append(res.Langs, t.String())
+	}
+	// sources only when there is one for every language
+	if sources := v[valuesSource]; len(sources) == len(res.Langs) {
+		res.Sources = make(map[string]Source)
+		for i, l := range res.Langs {
+			switch src := Source(sources[i]); src {
+			case SourceBrowser, SourceGeo, SourceOverride, SourceFallback, SourceStored:
+				res.Sources[l] = src
+			default:
+				return Result{}, fmt.Errorf("Invalid language source %q", sources[i])
+			}
+		}
+	}
+	res.Currency, res.CurrencyName = CurrencyFor(country)
+	res.CallingCode = CallingCodeFor(country)
+	return res, nil
+}
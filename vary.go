@@ -0,0 +1,51 @@
+package webgeo
+
+import (
+	"net/http"
+	"strings"
+)
+
+// GeoVary is added to the Vary header by the middlewares whose responses
+// depend on the location. Shared caches can't vary on the client IP: either
+// set it to a header the CDN fills with the visitor's country, e.g.
+// CloudFront-Viewer-Country or CF-IPCountry, and key the cache on it, or keep
+// such responses out of shared caches with Cache-Control: private, which
+// the redirecting middlewares do when GeoVary is empty.
+var GeoVary = ""
+
+// AddVary appends the fields missing from the Vary header of h
+func AddVary(h http.Header, fields ...string) {
+	present := make(map[string]bool)
+	for _, v := range h.Values("Vary") {
+		for _, f := range strings.Split(v, ",") {
+			present[strings.ToLower(strings.TrimSpace(f))] = true
+		}
+	}
+	if present["*"] {
+		return
+	}
+	for _, f := range fields {
+		if f != "" && !present[strings.ToLower(f)] {
+			h.Add("Vary", f)
+			present[strings.ToLower(f)] = true
+		}
+	}
+}
+
+// The request headers the languages of g depend on
+func (g *Geolocator) langVary() []string {
+	fields := []string{"Accept-Language"}
+	if g.langCookie != "" || g.localeCookie != "" {
+		fields = append(fields, "Cookie")
+	}
+	return fields
+}
+
+// Mark a response that depends on the location, see GeoVary
+func varyGeo(h http.Header) {
+	if GeoVary != "" {
+		AddVary(h, GeoVary)
+	} else if h.Get("Cache-Control") == "" {
+		h.Set("Cache-Control", "private")
+	}
+}
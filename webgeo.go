@@ -3,145 +3,449 @@ package webgeo
 import (
 	"encoding/csv"
 	"fmt"
-	"log"
 	"net"
 	"net/http"
-	"os"
-	"os/exec"
 	"strings"
-	"sync"
+	"time"
 
 	geoip2 "github.com/oschwald/geoip2-golang"
 	"golang.org/x/text/language"
 )
 
 var country2LangMap = mustBuildCountry2LangMap()
-var geoLangsCache = make(map[string][]string)
-var geoLangsCacheMutex = sync.RWMutex{}
+
+// DBPath is the location of the GeoLite2 City database. It is downloaded
+// there when missing.
+var DBPath = "GeoLite2-City.mmdb"
 
 type GeoRecord struct {
-	Ip      string `json:"ip"`
-	Cc      string `json:"cc"`
-	Country string `json:"country"`
-	City    string `json:"city"`
+	Ip       string `json:"ip"`
+	Cc       string `json:"cc"`
+	Country  string `json:"country"`
+	City     string `json:"city"`
+	TimeZone string `json:"time_zone"` // IANA name, e.g. "Europe/Warsaw"
+	// approximate location, both 0 when unknown
+	Latitude       float64 `json:"latitude"`
+	Longitude      float64 `json:"longitude"`
+	AccuracyRadius uint16  `json:"accuracy_radius"` // km
+	// AF, AN, AS, EU, NA, OC or SA
+	ContinentCode string `json:"continent_code"`
+	Continent     string `json:"continent"`
+	// ISO codes of the country the IP block is registered to (may differ from
+	// Cc e.g. for anycast ranges) and of the country represented by users of
+	// the IP, e.g. a military base abroad. The latter is usually empty.
+	RegisteredCountry  string `json:"registered_country,omitempty"`
+	RepresentedCountry string `json:"represented_country,omitempty"`
+	// membership as recorded in the database, no need to hardcode the EU list
+	IsInEuropeanUnion bool `json:"is_in_european_union"`
+	// traits flagged by the database, deprecated by MaxMind in favor of the
+	// Anonymous IP database and possibly never set by newer databases
+	IsAnonymousProxy    bool `json:"is_anonymous_proxy,omitempty"`
+	IsSatelliteProvider bool `json:"is_satellite_provider,omitempty"`
+	// only filled when ASNDBPath is set
+	AutonomousSystemNumber       uint   `json:"autonomous_system_number,omitempty"`
+	AutonomousSystemOrganization string `json:"autonomous_system_organization,omitempty"`
+	// states, provinces etc. ordered from the largest to the smallest
+	Subdivisions            []Subdivision `json:"subdivisions,omitempty"`
+	MostSpecificSubdivision Subdivision   `json:"most_specific_subdivision"`
+	// codes cleared by StrictCodes
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type Subdivision struct {
+	IsoCode string `json:"iso_code"` // without the country prefix, e.g. "CA" for California
+	Name    string `json:"name"`
+}
+
+// CacheEntry is the lookup of an IP as kept in a Cache
+type CacheEntry struct {
+	Geo *GeoRecord // nil when the lookup failed
+	Err error      // why the lookup failed
+
+	// derived from Geo, nil langs when not computed yet, e.g. after the
+	// entry went through a Cache that serializes it
+	country string // ZZ when unknown
+	langs   []language.Tag
+	at      time.Time // of the lookup, zero when unknown
+	// set by the Geolocator handing the entry to its Cache, see Lifetime
+	ttl    time.Duration
+	hasTTL bool
 }
 
 func CalcCountryAndLangs(r *http.Request) (string, []string) {
-	ipS, _, _ := net.SplitHostPort(r.RemoteAddr)
+	return defaultGeolocator.CalcCountryAndLangs(r)
+}
+
+// CalcCountryAndLangs returns the visitor's country code, "ZZ" when unknown,
+// and the languages from the browser by q-value followed by the ones of the
+// country, most preferred first
+func (g *Geolocator) CalcCountryAndLangs(r *http.Request) (string, []string) {
+	res, _ := g.calcCountryAndLangs(r)
+	return res.Country, res.Langs
+}
+
+// the language part of Resolve, with the error of CalcCountryAndLangsE
+func (g *Geolocator) calcCountryAndLangs(r *http.Request) (Result, error) {
+	res, err := g.mergeLangs(r)
+	countCountry(res.Country)
+	return res, err
+}
+
+// calcCountryAndLangs without counting the country in Diagnostics
+func (g *Geolocator) mergeLangs(r *http.Request) (Result, error) {
+	ipS := g.ClientIP(r)
 
 	var blangs = browserLangs(r)
-	glangs := geoLangs(ipS)
-	country := glangs[0]
-	glangs = glangs[1:]
+	e := g.lookupEntry(ipS)
+	err := e.Err
+	if ipS == "" {
+		err = ErrNoClientIP
+	}
+	geo, country, glangs := e.Geo, e.country, tagStrings(e.langs)
+	if LangLearner != nil {
+		// learned languages may have been stored by older versions
+		glangs = canonicalLangs(LangLearner.Rank(country, glangs))
+	}
+	blangs, glangs, conflict := resolveConflict(blangs, glangs)
+	if g.precedence == GeoAsFallback && len(blangs) > 0 {
+		glangs = []string{}
+	}
 	//fmt.Printf("blangs=%+v, glangs=%+v\n", blangs, glangs)
-	// get unique langs
-	var langMap = make(map[string]string)
-	for _, b := range blangs {
-		langMap[b] = ""
-	}
-	for _, g := range glangs {
-		langMap[g] = ""
-	}
-	// eliminate generic language codes when country specific langs are present
-	var countrySpecific = make(map[string]string)
-	for k, _ := range langMap {
-		if strings.Contains(k, "-") {
-			countrySpecific[k] = ""
+	// browser languages by q-value and the ones of the country, in the order
+	// of the precedence; the first is the source of a language in both
+	var langMap = make(map[string]Source)
+	var ordered = []string{}
+	add := func(l []string, src Source) {
+		for _, e := range l {
+			if _, pres := langMap[e]; !pres {
+				langMap[e] = src
+				ordered = append(ordered, e)
+			}
 		}
 	}
-	for k, _ := range countrySpecific {
-		delete(langMap, strings.Split(k, "-")[0])
+	// an explicit choice beats both
+	add(g.overrideLangs(r), SourceOverride)
+	// then the one negotiated before, so it doesn't change under the visitor
+	add(g.storedLangs(r), SourceStored)
+	if g.precedence == GeoFirst {
+		add(glangs, SourceGeo)
+		add(blangs, SourceBrowser)
+	} else {
+		add(blangs, SourceBrowser)
+		add(glangs, SourceGeo)
+	}
+	// a generic language code (zh, or zh-Hant) is replaced by the first
+	// more specific one of the same language (zh-Hant-TW), at the better of
+	// both positions, unless it was chosen explicitly or stored
+	var specific = make(map[string]string)
+	for _, l := range ordered {
+		parts := strings.Split(l, "-")
+		for i := 1; i < len(parts); i++ {
+			prefix := strings.Join(parts[:i], "-")
+			if _, pres := specific[prefix]; !pres {
+				specific[prefix] = l
+			}
+		}
 	}
 	var langs = []string{}
-	for k, _ := range langMap {
-		langs = append(langs, k)
+	for _, l := range ordered {
+		if s, pres := specific[l]; pres && langMap[l] != SourceOverride && langMap[l] != SourceStored {
+			delete(langMap, l)
+			l = s
+		}
+		if !contains(langs, l) {
+			langs = append(langs, l)
+		}
+	}
+	langs = g.limitLangs(langs, langMap)
+	if g.chains {
+		langs = g.expandChains(langs, langMap)
 	}
 
 	//fmt.Printf("\n\ncalcLangs: %v\n\n", langs)
-	return country, langs
+	return Result{Country: country, Langs: langs, Sources: langMap, Geo: geo, Conflict: conflict}, err
+}
+
+// Cap langs to WithMaxLangs, keeping the WithFallbackLang language in the
+// last place unless that is the preferred one
+func (g *Geolocator) limitLangs(langs []string, sources map[string]Source) []string {
+	fallback := ""
+	if g.fallbackLang != language.Und {
+		fallback = g.fallbackLang.String()
+		if !contains(langs, fallback) {
+			langs = append(langs, fallback)
+			sources[fallback] = SourceFallback
+		}
+	}
+	if g.maxLangs <= 0 || len(langs) <= g.maxLangs {
+		return langs
+	}
+	kept := append([]string{}, langs[:g.maxLangs]...)
+	if fallback != "" && !contains(kept, fallback) && len(kept) > 1 {
+		kept[len(kept)-1] = fallback
+	}
+	for _, l := range langs {
+		if !contains(kept, l) {
+			delete(sources, l)
+		}
+	}
+	return kept
+}
+
+// Extract the client IP from RemoteAddr. Handles "host:port" as well as bare
+// IPv4/IPv6 addresses. Returns "" when there is no IP at all, e.g. an empty
+// RemoteAddr or "@" for servers listening on a unix socket.
+func remoteIP(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	addr = strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+	if net.ParseIP(addr) == nil {
+		return ""
+	}
+	return addr
+}
+
+// BrowserLangs parses the Accept-Language header of the request, most
+// preferred first. No geo lookup is done. A malformed header gives no tags.
+func BrowserLangs(r *http.Request) []language.Tag {
+	tags, _ := BrowserLangWeights(r)
+	return tags
+}
+
+// BrowserLangWeights is BrowserLangs with the q-value of each tag
+func BrowserLangWeights(r *http.Request) ([]language.Tag, []float32) {
+	tags, q, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil {
+		return []language.Tag{}, []float32{}
+	}
+	return canonicalTags(tags), q
 }
 
 // Parse http request heeader "Accept-Language" to get the list of lang-region codes
 func browserLangs(r *http.Request) []string {
 	var langs = []string{}
-	tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
-	if err == nil {
-		for i := 0; i < len(tags); i++ {
-			langs = append(langs, tags[i].String())
-		}
+	for _, t := range BrowserLangs(r) {
+		langs = append(langs, withScript(t, "").String())
 	}
 	return langs
 }
 
-// returns list:
-// - 0th element is country code (ZZ if unidentified)
-// - alternative 1st and 2nd element are suggested languages for the region
-func geoLangs(ipS string) []string {
-	geoLangsCacheMutex.RLock()
-	if l, pres := geoLangsCache[ipS]; pres {
-		geoLangsCacheMutex.RUnlock()
-		return l
+// GeoLangs returns the country of ip, "ZZ" when unknown, and the languages
+// suggested for it, most used first. The error is a *DBError when the
+// database is broken. ip may include a port.
+func GeoLangs(ip string) (country string, langs []language.Tag, err error) {
+	return defaultGeolocator.GeoLangs(ip)
+}
+
+func (g *Geolocator) GeoLangs(ip string) (country string, langs []language.Tag, err error) {
+	ipS := remoteIP(ip)
+	if ipS == "" {
+		return "ZZ", []language.Tag{}, fmt.Errorf("Invalid IP %q", ip)
 	}
-	geoLangsCacheMutex.RUnlock()
+	e := g.lookupEntry(ipS)
+	return e.country, append([]language.Tag{}, e.langs...), e.Err
+}
 
-	ip := net.ParseIP(ipS)
-	geo, err := geolocate(ip)
-	var langs = []string{}
-	if len(geo.Cc) == 2 {
-		langs = append(langs, strings.ToUpper(geo.Cc))
+// cached lookup of ipS, the entry of an unknown location when ipS is ""
+func (g *Geolocator) lookupEntry(ipS string) CacheEntry {
+	if ipS == "" {
+		// nothing to locate, don't pollute the cache
+		return newGeoEntry(nil, nil)
+	}
+	if _, ok := g.cache.(noCache); ok {
+		g.counts.count(false)
+		return g.lookupUncached(ipS)
+	}
+	g.flushOnSwap()
+	key := g.cacheKey(ipS)
+	e, pres := g.cache.Get(key)
+	g.counts.count(pres)
+	if pres {
+		return e.complete().forIP(ipS, key)
+	}
+
+	// concurrent misses of the same IP share one lookup
+	v, _, _ := g.lookups.Do(key, func() (interface{}, error) {
+		e := g.withLifetime(g.lookupUncached(ipS))
+		// the lookup may have opened a new database
+		g.flushOnSwap()
+		g.cache.Set(key, e)
+		return e, nil
+	})
+	return v.(CacheEntry).forIP(ipS, key)
+}
+
+func (g *Geolocator) lookupUncached(ipS string) CacheEntry {
+	geo, err := g.geolocate(net.ParseIP(ipS))
+	if err != nil {
+		recordError(err)
+		geo = nil
+	}
+	return newGeoEntry(geo, err)
+}
+
+func newGeoEntry(geo *GeoRecord, err error) CacheEntry {
+	country, langs := countryLangs(geo)
+	return CacheEntry{Geo: geo, Err: err, country: country, langs: langs, at: time.Now()}
+}
+
+// Lifetime is how long the Geolocator handing e to a Cache wants it kept,
+// from its WithCacheTTL and WithNegativeCacheTTL settings or the package
+// defaults: 0 means until dropped to make room, less than 0 not at all. ok
+// is false for entries that didn't come from a Geolocator.
+func (e CacheEntry) Lifetime() (ttl time.Duration, ok bool) {
+	return e.ttl, e.hasTTL
+}
+
+// e with the Lifetime given by g
+func (g *Geolocator) withLifetime(e CacheEntry) CacheEntry {
+	e.ttl, e.hasTTL = g.entryLifetime(e), true
+	return e
+}
+
+// Negative tells whether the lookup failed or found no country. Such
+// entries are cached for NegativeCacheTTL only.
+func (e CacheEntry) Negative() bool {
+	return e.Err != nil || e.Geo == nil || e.Geo.Cc == ""
+}
+
+// e for ipS, which may differ from the IP of the lookup when cached by its
+// network key
+func (e CacheEntry) forIP(ipS, key string) CacheEntry {
+	if key != ipS && e.Geo != nil && e.Geo.Ip != ipS {
+		geo := *e.Geo
+		geo.Ip = ipS
+		e.Geo = &geo
+	}
+	return e
+}
+
+// e with the fields derived from Geo
+func (e CacheEntry) complete() CacheEntry {
+	if e.langs == nil {
+		at := e.at
+		e = newGeoEntry(e.Geo, e.Err)
+		e.at = at
+	}
+	return e
+}
+
+// country code of a GeoRecord, ZZ if unidentified, with its languages
+func countryLangs(geo *GeoRecord) (string, []language.Tag) {
+	langs := []language.Tag{}
+	if geo == nil || len(geo.Cc) != 2 {
+		return "ZZ", langs
+	}
+	cc := strings.ToUpper(geo.Cc)
+	// comma separated languages
+	if csl, pres := country2LangMap[cc]; pres {
+		tags, _, err := language.ParseAcceptLanguage(csl)
 		if err == nil {
-			// comma separated languages
-			if csl, pres := country2LangMap[strings.ToUpper(geo.Cc)]; pres {
-				tags, _, err := language.ParseAcceptLanguage(csl)
-				if err == nil {
-					for i := 0; i < len(tags); i++ {
-						langs = append(langs, tags[i].String())
-					}
-				}
+			for _, t := range canonicalTags(tags) {
+				langs = append(langs, withScript(t, cc))
 			}
 		}
-	} else {
-		langs = append(langs, "ZZ")
 	}
-	geoLangsCacheMutex.Lock()
-	geoLangsCache[ipS] = langs
-	geoLangsCacheMutex.Unlock()
-	//fmt.Printf("\n\ngeoLangs: %v\n\n", langs)
-	return langs
+	return cc, langs
+}
+
+func tagStrings(tags []language.Tag) []string {
+	l := make([]string, len(tags))
+	for i, t := range tags {
+		l[i] = t.String()
+	}
+	return l
 }
 
 func geolocate(ip net.IP) (*GeoRecord, error) {
-	mmdbfile := "GeoLite2-City.mmdb"
+	return defaultGeolocator.geolocate(ip)
+}
+
+func (g *Geolocator) geolocate(ip net.IP) (*GeoRecord, error) {
+	geos, errs := g.geolocateAll([]net.IP{ip})
+	return geos[0], errs[0]
+}
 
-	if _, err := os.Stat(mmdbfile); err != nil {
-		log.Printf("%s does not exist. Checking for gz...", mmdbfile)
-		if _, err := os.Stat(mmdbfile + ".gz"); err != nil {
-			log.Printf("%s.gz does not exist. Downloading...", mmdbfile)
-			exec.Command("wget", "-N", "http://geolite.maxmind.com/download/geoip/database/GeoLite2-City.mmdb.gz").Output()
+// Look up many IPs acquiring each database reader once
+func (g *Geolocator) geolocateAll(ips []net.IP) ([]*GeoRecord, []error) {
+	geos := make([]*GeoRecord, len(ips))
+	errs := make([]error, len(ips))
+	err := g.withDB(func(db *geoip2.Reader) error {
+		for i, ip := range ips {
+			record, err := db.City(ip)
+			if err != nil {
+				errs[i] = &DBError{g.cityPath(), err}
+				continue
+			}
+			geos[i] = newGeoRecord(ip, record)
 		}
-		if _, err := os.Stat(mmdbfile + ".gz"); err != nil {
-			return nil, fmt.Errorf("Could not download %s.gz", mmdbfile)
+		return nil
+	})
+	if err != nil {
+		for i := range errs {
+			errs[i] = &DBError{g.cityPath(), err}
 		}
-		log.Printf("Unzip %s.gz", mmdbfile)
-		exec.Command("gunzip", mmdbfile+".gz").Output()
-		if _, err := os.Stat(mmdbfile); err != nil {
-			return nil, fmt.Errorf("Could not unzip %s.gz", mmdbfile)
+		g.fallbackAll(ips, geos, errs)
+		return geos, errs
+	}
+	g.fallbackAll(ips, geos, errs)
+	if g.asnPath() != "" {
+		// a broken ASN database shouldn't break geolocation
+		err := g.withASNDB(func(db *geoip2.Reader) error {
+			for i, geo := range geos {
+				if geo == nil {
+					continue
+				}
+				asn, err := db.ASN(ips[i])
+				if err != nil {
+					recordError(err)
+					continue
+				}
+				geo.AutonomousSystemNumber = asn.AutonomousSystemNumber
+				geo.AutonomousSystemOrganization = asn.AutonomousSystemOrganization
+			}
+			return nil
+		})
+		if err != nil {
+			recordError(err)
 		}
 	}
+	return geos, errs
+}
 
-	db, err := geoip2.Open(mmdbfile)
-	if err != nil {
-		return nil, err
+func newGeoRecord(ip net.IP, record *geoip2.City) *GeoRecord {
+	geo := &GeoRecord{
+		Ip:       ip.String(),
+		Cc:       record.Country.IsoCode,
+		Country:  record.Country.Names["en"],
+		City:     record.City.Names["en"],
+		TimeZone: record.Location.TimeZone,
+
+		Latitude:       record.Location.Latitude,
+		Longitude:      record.Location.Longitude,
+		AccuracyRadius: record.Location.AccuracyRadius,
+
+		ContinentCode: record.Continent.Code,
+		Continent:     record.Continent.Names["en"],
+
+		RegisteredCountry:  record.RegisteredCountry.IsoCode,
+		RepresentedCountry: record.RepresentedCountry.IsoCode,
+		IsInEuropeanUnion:  record.Country.IsInEuropeanUnion,
+
+		IsAnonymousProxy:    record.Traits.IsAnonymousProxy,
+		IsSatelliteProvider: record.Traits.IsSatelliteProvider,
 	}
-	defer db.Close()
-	record, err := db.City(ip)
-	if err != nil {
-		return nil, err
+	for _, s := range record.Subdivisions {
+		geo.Subdivisions = append(geo.Subdivisions, Subdivision{s.IsoCode, s.Names["en"]})
+	}
+	if n := len(geo.Subdivisions); n > 0 {
+		geo.MostSpecificSubdivision = geo.Subdivisions[n-1]
+	}
+	if StrictCodes {
+		validateCodes(geo)
 	}
-	cc := record.Country.IsoCode
-	country := record.Country.Names["en"]
-	city := record.City.Names["en"]
-	return &GeoRecord{ip.String(), cc, country, city}, nil
+	return geo
 }
 
 func readCountryInfoTable() ([][]string, error) {
@@ -188,254 +492,256 @@ func mustBuildCountry2LangMap() map[string]string {
 	return m
 }
 
+// columns: ISO code, name, continent, TLD, currency code, currency name,
+// languages, calling code, approximate population, neighbours
 var countryInfoTable = `
-AD,Andorra,EU,.ad,EUR,Euro,ca
-AE,United Arab Emirates,AS,.ae,AED,Dirham,"ar-AE,fa,en,hi,ur"
-AF,Afghanistan,AS,.af,AFN,Afghani,"fa-AF,ps,uz-AF,tk"
-AG,Antigua and Barbuda,NA,.ag,XCD,Dollar,en-AG
-AI,Anguilla,NA,.ai,XCD,Dollar,en-AI
-AL,Albania,EU,.al,ALL,Lek,"sq,el"
-AM,Armenia,AS,.am,AMD,Dram,hy
-AO,Angola,AF,.ao,AOA,Kwanza,pt-AO
-AR,Argentina,SA,.ar,ARS,Peso,"es-AR,en,it,de,fr,gn"
-AS,American Samoa,OC,.as,USD,Dollar,"en-AS,sm,to"
-AT,Austria,EU,.at,EUR,Euro,"de-AT,hr,hu,sl"
-AU,Australia,OC,.au,AUD,Dollar,en-AU
-AW,Aruba,NA,.aw,AWG,Guilder,"nl-AW,es,en"
-AX,Aland Islands,EU,.ax,EUR,Euro,sv-AX
-AZ,Azerbaijan,AS,.az,AZN,Manat,"az,ru,hy"
-BA,Bosnia and Herzegovina,EU,.ba,BAM,Marka,"bs,hr-BA,sr-BA"
-BB,Barbados,NA,.bb,BBD,Dollar,en-BB
-BD,Bangladesh,AS,.bd,BDT,Taka,"bn-BD,en"
-BE,Belgium,EU,.be,EUR,Euro,"nl-BE,fr-BE,de-BE"
-BF,Burkina Faso,AF,.bf,XOF,Franc,fr-BF
-BG,Bulgaria,EU,.bg,BGN,Lev,"bg,tr-BG,rom"
-BH,Bahrain,AS,.bh,BHD,Dinar,"ar-BH,en,fa,ur"
-BI,Burundi,AF,.bi,BIF,Franc,"fr-BI,rn"
-BJ,Benin,AF,.bj,XOF,Franc,fr-BJ
-BL,Saint Barthelemy,NA,.gp,EUR,Euro,fr
-BM,Bermuda,NA,.bm,BMD,Dollar,"en-BM,pt"
-BN,Brunei,AS,.bn,BND,Dollar,"ms-BN,en-BN"
-BO,Bolivia,SA,.bo,BOB,Boliviano,"es-BO,qu,ay"
-BQ,"Bonaire, Saint Eustatius and Saba ",NA,.bq,USD,Dollar,"nl,pap,en"
-BR,Brazil,SA,.br,BRL,Real,"pt-BR,es,en,fr"
-BS,Bahamas,NA,.bs,BSD,Dollar,en-BS
-BT,Bhutan,AS,.bt,BTN,Ngultrum,dz
-BW,Botswana,AF,.bw,BWP,Pula,"en-BW,tn-BW"
-BY,Belarus,EU,.by,BYR,Ruble,"be,ru"
-BZ,Belize,NA,.bz,BZD,Dollar,"en-BZ,es"
-CA,Canada,NA,.ca,CAD,Dollar,"en-CA,fr-CA,iu"
-CC,Cocos Islands,AS,.cc,AUD,Dollar,"ms-CC,en"
-CD,Democratic Republic of the Congo,AF,.cd,CDF,Franc,"fr-CD,ln,kg"
-CF,Central African Republic,AF,.cf,XAF,Franc,"fr-CF,sg,ln,kg"
-CG,Republic of the Congo,AF,.cg,XAF,Franc,"fr-CG,kg,ln-CG"
-CH,Switzerland,EU,.ch,CHF,Franc,"de-CH,fr-CH,it-CH,rm"
-CI,Ivory Coast,AF,.ci,XOF,Franc,fr-CI
-CK,Cook Islands,OC,.ck,NZD,Dollar,"en-CK,mi"
-CL,Chile,SA,.cl,CLP,Peso,es-CL
-CM,Cameroon,AF,.cm,XAF,Franc,"en-CM,fr-CM"
-CN,China,AS,.cn,CNY,Yuan Renminbi,"zh-CN,yue,wuu,dta,ug,za"
-CO,Colombia,SA,.co,COP,Peso,es-CO
-CR,Costa Rica,NA,.cr,CRC,Colon,"es-CR,en"
-CU,Cuba,NA,.cu,CUP,Peso,es-CU
-CV,Cape Verde,AF,.cv,CVE,Escudo,pt-CV
-CW,Curacao,NA,.cw,ANG,Guilder,"nl,pap"
-CX,Christmas Island,AS,.cx,AUD,Dollar,"en,zh,ms-CC"
-CY,Cyprus,EU,.cy,EUR,Euro,"el-CY,tr-CY,en"
-CZ,Czechia,EU,.cz,CZK,Koruna,"cs,sk"
-DE,Germany,EU,.de,EUR,Euro,de
-DJ,Djibouti,AF,.dj,DJF,Franc,"fr-DJ,ar,so-DJ,aa"
-DK,Denmark,EU,.dk,DKK,Krone,"da-DK,en,fo,de-DK"
-DM,Dominica,NA,.dm,XCD,Dollar,en-DM
-DO,Dominican Republic,NA,.do,DOP,Peso,es-DO
-DZ,Algeria,AF,.dz,DZD,Dinar,ar-DZ
-EC,Ecuador,SA,.ec,USD,Dollar,es-EC
-EE,Estonia,EU,.ee,EUR,Euro,"et,ru"
-EG,Egypt,AF,.eg,EGP,Pound,"ar-EG,en,fr"
-EH,Western Sahara,AF,.eh,MAD,Dirham,"ar,mey"
-ER,Eritrea,AF,.er,ERN,Nakfa,"aa-ER,ar,tig,kun,ti-ER"
-ES,Spain,EU,.es,EUR,Euro,"es-ES,ca,gl,eu,oc"
-ET,Ethiopia,AF,.et,ETB,Birr,"am,en-ET,om-ET,ti-ET,so-ET,sid"
-FI,Finland,EU,.fi,EUR,Euro,"fi-FI,sv-FI,smn"
-FJ,Fiji,OC,.fj,FJD,Dollar,"en-FJ,fj"
-FK,Falkland Islands,SA,.fk,FKP,Pound,en-FK
-FM,Micronesia,OC,.fm,USD,Dollar,"en-FM,chk,pon,yap,kos,uli,woe,nkr,kpg"
-FO,Faroe Islands,EU,.fo,DKK,Krone,"fo,da-FO"
-FR,France,EU,.fr,EUR,Euro,"fr-FR,frp,br,co,ca,eu,oc"
-GA,Gabon,AF,.ga,XAF,Franc,fr-GA
-GB,United Kingdom,EU,.uk,GBP,Pound,"en-GB,cy-GB,gd"
-GD,Grenada,NA,.gd,XCD,Dollar,en-GD
-GE,Georgia,AS,.ge,GEL,Lari,"ka,ru,hy,az"
-GF,French Guiana,SA,.gf,EUR,Euro,fr-GF
-GG,Guernsey,EU,.gg,GBP,Pound,"en,fr"
-GH,Ghana,AF,.gh,GHS,Cedi,"en-GH,ak,ee,tw"
-GI,Gibraltar,EU,.gi,GIP,Pound,"en-GI,es,it,pt"
-GL,Greenland,NA,.gl,DKK,Krone,"kl,da-GL,en"
-GM,Gambia,AF,.gm,GMD,Dalasi,"en-GM,mnk,wof,wo,ff"
-GN,Guinea,AF,.gn,GNF,Franc,fr-GN
-GP,Guadeloupe,NA,.gp,EUR,Euro,fr-GP
-GQ,Equatorial Guinea,AF,.gq,XAF,Franc,"es-GQ,fr"
-GR,Greece,EU,.gr,EUR,Euro,"el-GR,en,fr"
-GS,South Georgia and the South Sandwich Islands,AN,.gs,GBP,Pound,en
-GT,Guatemala,NA,.gt,GTQ,Quetzal,es-GT
-GU,Guam,OC,.gu,USD,Dollar,"en-GU,ch-GU"
-GW,Guinea-Bissau,AF,.gw,XOF,Franc,"pt-GW,pov"
-GY,Guyana,SA,.gy,GYD,Dollar,en-GY
-HK,Hong Kong,AS,.hk,HKD,Dollar,"zh-HK,yue,zh,en"
-HN,Honduras,NA,.hn,HNL,Lempira,es-HN
-HR,Croatia,EU,.hr,HRK,Kuna,"hr-HR,sr"
-HT,Haiti,NA,.ht,HTG,Gourde,"ht,fr-HT"
-HU,Hungary,EU,.hu,HUF,Forint,hu-HU
-ID,Indonesia,AS,.id,IDR,Rupiah,"id,en,nl,jv"
-IE,Ireland,EU,.ie,EUR,Euro,"en-IE,ga-IE"
-IL,Israel,AS,.il,ILS,Shekel,"he,ar-IL,en-IL,"
-IM,Isle of Man,EU,.im,GBP,Pound,"en,gv"
-IN,India,AS,.in,INR,Rupee,"en-IN,hi,bn,te,mr,ta,ur,gu,kn,ml,or,pa,as,bh,sat,ks,ne,sd,kok,doi,mni,sit,sa,fr,lus,inc"
-IO,British Indian Ocean Territory,AS,.io,USD,Dollar,en-IO
-IQ,Iraq,AS,.iq,IQD,Dinar,"ar-IQ,ku,hy"
-IR,Iran,AS,.ir,IRR,Rial,"fa-IR,ku"
-IS,Iceland,EU,.is,ISK,Krona,"is,en,de,da,sv,no"
-IT,Italy,EU,.it,EUR,Euro,"it-IT,de-IT,fr-IT,sc,ca,co,sl"
-JE,Jersey,EU,.je,GBP,Pound,"en,pt"
-JM,Jamaica,NA,.jm,JMD,Dollar,en-JM
-JO,Jordan,AS,.jo,JOD,Dinar,"ar-JO,en"
-JP,Japan,AS,.jp,JPY,Yen,ja
-KE,Kenya,AF,.ke,KES,Shilling,"en-KE,sw-KE"
-KG,Kyrgyzstan,AS,.kg,KGS,Som,"ky,ru,uz"
-KH,Cambodia,AS,.kh,KHR,Riels,"km,fr,en"
-KI,Kiribati,OC,.ki,AUD,Dollar,"en-KI,gil"
-KM,Comoros,AF,.km,KMF,Franc,"ar,fr-KM"
-KN,Saint Kitts and Nevis,NA,.kn,XCD,Dollar,en-KN
-KP,North Korea,AS,.kp,KPW,Won,ko-KP
-KR,South Korea,AS,.kr,KRW,Won,"ko-KR,en"
-XK,Kosovo,EU,,EUR,Euro,"sq,sr"
-KW,Kuwait,AS,.kw,KWD,Dinar,"ar-KW,en"
-KY,Cayman Islands,NA,.ky,KYD,Dollar,en-KY
-KZ,Kazakhstan,AS,.kz,KZT,Tenge,"kk,ru"
-LA,Laos,AS,.la,LAK,Kip,"lo,fr,en"
-LB,Lebanon,AS,.lb,LBP,Pound,"ar-LB,fr-LB,en,hy"
-LC,Saint Lucia,NA,.lc,XCD,Dollar,en-LC
-LI,Liechtenstein,EU,.li,CHF,Franc,de-LI
-LK,Sri Lanka,AS,.lk,LKR,Rupee,"si,ta,en"
-LR,Liberia,AF,.lr,LRD,Dollar,en-LR
-LS,Lesotho,AF,.ls,LSL,Loti,"en-LS,st,zu,xh"
-LT,Lithuania,EU,.lt,EUR,Euro,"lt,ru,pl"
-LU,Luxembourg,EU,.lu,EUR,Euro,"lb,de-LU,fr-LU"
-LV,Latvia,EU,.lv,EUR,Euro,"lv,ru,lt"
-LY,Libya,AF,.ly,LYD,Dinar,"ar-LY,it,en"
-MA,Morocco,AF,.ma,MAD,Dirham,"ar-MA,ber,fr"
-MC,Monaco,EU,.mc,EUR,Euro,"fr-MC,en,it"
-MD,Moldova,EU,.md,MDL,Leu,"ro,ru,gag,tr"
-ME,Montenegro,EU,.me,EUR,Euro,"sr,hu,bs,sq,hr,rom"
-MF,Saint Martin,NA,.gp,EUR,Euro,fr
-MG,Madagascar,AF,.mg,MGA,Ariary,"fr-MG,mg"
-MH,Marshall Islands,OC,.mh,USD,Dollar,"mh,en-MH"
-MK,Macedonia,EU,.mk,MKD,Denar,"mk,sq,tr,rmm,sr"
-ML,Mali,AF,.ml,XOF,Franc,"fr-ML,bm"
-MM,Myanmar,AS,.mm,MMK,Kyat,my
-MN,Mongolia,AS,.mn,MNT,Tugrik,"mn,ru"
-MO,Macao,AS,.mo,MOP,Pataca,"zh,zh-MO,pt"
-MP,Northern Mariana Islands,OC,.mp,USD,Dollar,"fil,tl,zh,ch-MP,en-MP"
-MQ,Martinique,NA,.mq,EUR,Euro,fr-MQ
-MR,Mauritania,AF,.mr,MRO,Ouguiya,"ar-MR,fuc,snk,fr,mey,wo"
-MS,Montserrat,NA,.ms,XCD,Dollar,en-MS
-MT,Malta,EU,.mt,EUR,Euro,"mt,en-MT"
-MU,Mauritius,AF,.mu,MUR,Rupee,"en-MU,bho,fr"
-MV,Maldives,AS,.mv,MVR,Rufiyaa,"dv,en"
-MW,Malawi,AF,.mw,MWK,Kwacha,"ny,yao,tum,swk"
-MX,Mexico,NA,.mx,MXN,Peso,es-MX
-MY,Malaysia,AS,.my,MYR,Ringgit,"ms-MY,en,zh,ta,te,ml,pa,th"
-MZ,Mozambique,AF,.mz,MZN,Metical,"pt-MZ,vmw"
-NA,Namibia,AF,.na,NAD,Dollar,"en-NA,af,de,hz,naq"
-NC,New Caledonia,OC,.nc,XPF,Franc,fr-NC
-NE,Niger,AF,.ne,XOF,Franc,"fr-NE,ha,kr,dje"
-NF,Norfolk Island,OC,.nf,AUD,Dollar,en-NF
-NG,Nigeria,AF,.ng,NGN,Naira,"en-NG,ha,yo,ig,ff"
-NI,Nicaragua,NA,.ni,NIO,Cordoba,"es-NI,en"
-NL,Netherlands,EU,.nl,EUR,Euro,"nl-NL,fy-NL"
-NO,Norway,EU,.no,NOK,Krone,"no,nb,nn,se,fi"
-NP,Nepal,AS,.np,NPR,Rupee,"ne,en"
-NR,Nauru,OC,.nr,AUD,Dollar,"na,en-NR"
-NU,Niue,OC,.nu,NZD,Dollar,"niu,en-NU"
-NZ,New Zealand,OC,.nz,NZD,Dollar,"en-NZ,mi"
-OM,Oman,AS,.om,OMR,Rial,"ar-OM,en,bal,ur"
-PA,Panama,NA,.pa,PAB,Balboa,"es-PA,en"
-PE,Peru,SA,.pe,PEN,Sol,"es-PE,qu,ay"
-PF,French Polynesia,OC,.pf,XPF,Franc,"fr-PF,ty"
-PG,Papua New Guinea,OC,.pg,PGK,Kina,"en-PG,ho,meu,tpi"
-PH,Philippines,AS,.ph,PHP,Peso,"tl,en-PH,fil"
-PK,Pakistan,AS,.pk,PKR,Rupee,"ur-PK,en-PK,pa,sd,ps,brh"
-PL,Poland,EU,.pl,PLN,Zloty,pl
-PM,Saint Pierre and Miquelon,NA,.pm,EUR,Euro,fr-PM
-PN,Pitcairn,OC,.pn,NZD,Dollar,en-PN
-PR,Puerto Rico,NA,.pr,USD,Dollar,"en-PR,es-PR"
-PS,Palestinian Territory,AS,.ps,ILS,Shekel,ar-PS
-PT,Portugal,EU,.pt,EUR,Euro,"pt-PT,mwl"
-PW,Palau,OC,.pw,USD,Dollar,"pau,sov,en-PW,tox,ja,fil,zh"
-PY,Paraguay,SA,.py,PYG,Guarani,"es-PY,gn"
-QA,Qatar,AS,.qa,QAR,Rial,"ar-QA,es"
-RE,Reunion,AF,.re,EUR,Euro,fr-RE
-RO,Romania,EU,.ro,RON,Leu,"ro,hu,rom"
-RS,Serbia,EU,.rs,RSD,Dinar,"sr,hu,bs,rom"
-RU,Russia,EU,.ru,RUB,Ruble,"ru"
-RW,Rwanda,AF,.rw,RWF,Franc,"rw,en-RW,fr-RW,sw"
-SA,Saudi Arabia,AS,.sa,SAR,Rial,ar-SA
-SB,Solomon Islands,OC,.sb,SBD,Dollar,"en-SB,tpi"
-SC,Seychelles,AF,.sc,SCR,Rupee,"en-SC,fr-SC"
-SD,Sudan,AF,.sd,SDG,Pound,"ar-SD,en,fia"
-SS,South Sudan,AF,,SSP,Pound,en
-SE,Sweden,EU,.se,SEK,Krona,"sv-SE,se,sma,fi-SE"
-SG,Singapore,AS,.sg,SGD,Dollar,"cmn,en-SG,ms-SG,ta-SG,zh-SG"
-SH,Saint Helena,AF,.sh,SHP,Pound,en-SH
-SI,Slovenia,EU,.si,EUR,Euro,"sl,sh"
-SJ,Svalbard and Jan Mayen,EU,.sj,NOK,Krone,"no,ru"
-SK,Slovakia,EU,.sk,EUR,Euro,"sk,hu"
-SL,Sierra Leone,AF,.sl,SLL,Leone,"en-SL,men,tem"
-SM,San Marino,EU,.sm,EUR,Euro,it-SM
-SN,Senegal,AF,.sn,XOF,Franc,"fr-SN,wo,fuc,mnk"
-SO,Somalia,AF,.so,SOS,Shilling,"so-SO,ar-SO,it,en-SO"
-SR,Suriname,SA,.sr,SRD,Dollar,"nl-SR,en,srn,hns,jv"
-ST,Sao Tome and Principe,AF,.st,STD,Dobra,pt-ST
-SV,El Salvador,NA,.sv,USD,Dollar,es-SV
-SX,Sint Maarten,NA,.sx,ANG,Guilder,"nl,en"
-SY,Syria,AS,.sy,SYP,Pound,"ar-SY,ku,hy,arc,fr,en"
-SZ,Swaziland,AF,.sz,SZL,Lilangeni,"en-SZ,ss-SZ"
-TC,Turks and Caicos Islands,NA,.tc,USD,Dollar,en-TC
-TD,Chad,AF,.td,XAF,Franc,"fr-TD,ar-TD,sre"
-TF,French Southern Territories,AN,.tf,EUR,Euro  ,fr
-TG,Togo,AF,.tg,XOF,Franc,"fr-TG,ee,hna,kbp,dag,ha"
-TH,Thailand,AS,.th,THB,Baht,"th,en"
-TJ,Tajikistan,AS,.tj,TJS,Somoni,"tg,ru"
-TK,Tokelau,OC,.tk,NZD,Dollar,"tkl,en-TK"
-TL,East Timor,OC,.tl,USD,Dollar,"tet,pt-TL,id,en"
-TM,Turkmenistan,AS,.tm,TMT,Manat,"tk,ru,uz"
-TN,Tunisia,AF,.tn,TND,Dinar,"ar-TN,fr"
-TO,Tonga,OC,.to,TOP,Pa'anga,"to,en-TO"
-TR,Turkey,AS,.tr,TRY,Lira,"tr-TR,ku,diq,az,av"
-TT,Trinidad and Tobago,NA,.tt,TTD,Dollar,"en-TT,hns,fr,es,zh"
-TV,Tuvalu,OC,.tv,AUD,Dollar,"tvl,en,sm,gil"
-TW,Taiwan,AS,.tw,TWD,Dollar,"zh-TW,zh,nan,hak"
-TZ,Tanzania,AF,.tz,TZS,Shilling,"sw-TZ,en,ar"
-UA,Ukraine,EU,.ua,UAH,Hryvnia,"uk,ru-UA,rom,pl,hu"
-UG,Uganda,AF,.ug,UGX,Shilling,"en-UG,lg,sw,ar"
-UM,United States Minor Outlying Islands,OC,.um,USD,Dollar ,en-UM
-US,United States,NA,.us,USD,Dollar,"en-US,es-US,haw,fr"
-UY,Uruguay,SA,.uy,UYU,Peso,es-UY
-UZ,Uzbekistan,AS,.uz,UZS,Som,"uz,ru,tg"
-VA,Vatican,EU,.va,EUR,Euro,"la,it,fr"
-VC,Saint Vincent and the Grenadines,NA,.vc,XCD,Dollar,"en-VC,fr"
-VE,Venezuela,SA,.ve,VEF,Bolivar,es-VE
-VG,British Virgin Islands,NA,.vg,USD,Dollar,en-VG
-VI,U.S. Virgin Islands,NA,.vi,USD,Dollar,en-VI
-VN,Vietnam,AS,.vn,VND,Dong,"vi,en,fr,zh,km"
-VU,Vanuatu,OC,.vu,VUV,Vatu,"bi,en-VU,fr-VU"
-WF,Wallis and Futuna,OC,.wf,XPF,Franc,"wls,fud,fr-WF"
-WS,Samoa,OC,.ws,WST,Tala,"sm,en-WS"
-YE,Yemen,AS,.ye,YER,Rial,ar-YE
-YT,Mayotte,AF,.yt,EUR,Euro,fr-YT
-ZA,South Africa,AF,.za,ZAR,Rand,"en-ZA,zu,xh,af,nso,tn,st,ts,ss,ve,nr"
-ZM,Zambia,AF,.zm,ZMW,Kwacha,"en-ZM,bem,loz,lun,lue,ny,toi"
-ZW,Zimbabwe,AF,.zw,ZWL,Dollar,"en-ZW,sn,nr,nd"
-CS,Serbia and Montenegro,EU,.cs,RSD,Dinar,"cu,hu,sq,sr"
-AN,Netherlands Antilles,NA,.an,ANG,Guilder,"nl-AN,en,es"
+AD,Andorra,EU,.ad,EUR,Euro,ca,376,77000,"ES,FR"
+AE,United Arab Emirates,AS,.ae,AED,Dirham,"ar-AE,fa,en,hi,ur",971,9890000,"SA,OM"
+AF,Afghanistan,AS,.af,AFN,Afghani,"fa-AF,ps,uz-AF,tk",93,38900000,"TM,CN,IR,TJ,PK,UZ"
+AG,Antigua and Barbuda,NA,.ag,XCD,Dollar,en-AG,1,98000,
+AI,Anguilla,NA,.ai,XCD,Dollar,en-AI,1,15000,
+AL,Albania,EU,.al,ALL,Lek,"sq,el",355,2840000,"MK,GR,ME,XK"
+AM,Armenia,AS,.am,AMD,Dram,hy,374,2960000,"GE,IR,AZ,TR"
+AO,Angola,AF,.ao,AOA,Kwanza,pt-AO,244,32900000,"CD,NA,ZM,CG"
+AR,Argentina,SA,.ar,ARS,Peso,"es-AR,en,it,de,fr,gn",54,45200000,"CL,BO,UY,PY,BR"
+AS,American Samoa,OC,.as,USD,Dollar,"en-AS,sm,to",1,55000,
+AT,Austria,EU,.at,EUR,Euro,"de-AT,hr,hu,sl",43,9010000,"CH,DE,HU,SK,CZ,IT,SI,LI"
+AU,Australia,OC,.au,AUD,Dollar,en-AU,61,25700000,
+AW,Aruba,NA,.aw,AWG,Guilder,"nl-AW,es,en",297,107000,
+AX,Aland Islands,EU,.ax,EUR,Euro,sv-AX,358,30000,
+AZ,Azerbaijan,AS,.az,AZN,Manat,"az,ru,hy",994,10100000,"GE,IR,AM,TR,RU"
+BA,Bosnia and Herzegovina,EU,.ba,BAM,Marka,"bs,hr-BA,sr-BA",387,3280000,"HR,ME,RS"
+BB,Barbados,NA,.bb,BBD,Dollar,en-BB,1,287000,
+BD,Bangladesh,AS,.bd,BDT,Taka,"bn-BD,en",880,165000000,"MM,IN"
+BE,Belgium,EU,.be,EUR,Euro,"nl-BE,fr-BE,de-BE",32,11600000,"DE,NL,LU,FR"
+BF,Burkina Faso,AF,.bf,XOF,Franc,fr-BF,226,20900000,"NE,BJ,GH,CI,TG,ML"
+BG,Bulgaria,EU,.bg,BGN,Lev,"bg,tr-BG,rom",359,6950000,"MK,GR,RO,TR,RS"
+BH,Bahrain,AS,.bh,BHD,Dinar,"ar-BH,en,fa,ur",973,1700000,
+BI,Burundi,AF,.bi,BIF,Franc,"fr-BI,rn",257,11900000,"TZ,CD,RW"
+BJ,Benin,AF,.bj,XOF,Franc,fr-BJ,229,12100000,"NE,TG,BF,NG"
+BL,Saint Barthelemy,NA,.gp,EUR,Euro,fr,590,10000,
+BM,Bermuda,NA,.bm,BMD,Dollar,"en-BM,pt",1,62000,
+BN,Brunei,AS,.bn,BND,Dollar,"ms-BN,en-BN",673,437000,MY
+BO,Bolivia,SA,.bo,BOB,Boliviano,"es-BO,qu,ay",591,11700000,"PE,CL,PY,BR,AR"
+BQ,"Bonaire, Saint Eustatius and Saba ",NA,.bq,USD,Dollar,"nl,pap,en",599,26000,
+BR,Brazil,SA,.br,BRL,Real,"pt-BR,es,en,fr",55,213000000,"BO,CO,GF,GY,VE,UY,PY,AR,SR,PE"
+BS,Bahamas,NA,.bs,BSD,Dollar,en-BS,1,393000,
+BT,Bhutan,AS,.bt,BTN,Ngultrum,dz,975,772000,"CN,IN"
+BW,Botswana,AF,.bw,BWP,Pula,"en-BW,tn-BW",267,2350000,"ZW,ZA,NA,ZM"
+BY,Belarus,EU,.by,BYR,Ruble,"be,ru",375,9400000,"LV,LT,UA,RU,PL"
+BZ,Belize,NA,.bz,BZD,Dollar,"en-BZ,es",501,398000,"GT,MX"
+CA,Canada,NA,.ca,CAD,Dollar,"en-CA,fr-CA,iu",1,38000000,US
+CC,Cocos Islands,AS,.cc,AUD,Dollar,"ms-CC,en",61,600,
+CD,Democratic Republic of the Congo,AF,.cd,CDF,Franc,"fr-CD,ln,kg",243,89600000,"TZ,CF,SS,CG,UG,RW,BI,ZM,AO"
+CF,Central African Republic,AF,.cf,XAF,Franc,"fr-CF,sg,ln,kg",236,4830000,"TD,SD,CD,SS,CM,CG"
+CG,Republic of the Congo,AF,.cg,XAF,Franc,"fr-CG,kg,ln-CG",242,5520000,"CF,GA,CD,CM,AO"
+CH,Switzerland,EU,.ch,CHF,Franc,"de-CH,fr-CH,it-CH,rm",41,8650000,"DE,IT,LI,FR,AT"
+CI,Ivory Coast,AF,.ci,XOF,Franc,fr-CI,225,26400000,"LR,GH,GN,BF,ML"
+CK,Cook Islands,OC,.ck,NZD,Dollar,"en-CK,mi",682,17000,
+CL,Chile,SA,.cl,CLP,Peso,es-CL,56,19100000,"PE,BO,AR"
+CM,Cameroon,AF,.cm,XAF,Franc,"en-CM,fr-CM",237,26500000,"TD,CF,GA,GQ,CG,NG"
+CN,China,AS,.cn,CNY,Yuan Renminbi,"zh-CN,yue,wuu,dta,ug,za",86,1410000000,"LA,BT,TJ,KZ,MN,AF,NP,MM,KG,PK,KP,RU,VN,IN,HK,MO"
+CO,Colombia,SA,.co,COP,Peso,es-CO,57,50900000,"EC,PE,PA,BR,VE"
+CR,Costa Rica,NA,.cr,CRC,Colon,"es-CR,en",506,5090000,"PA,NI"
+CU,Cuba,NA,.cu,CUP,Peso,es-CU,53,11300000,
+CV,Cape Verde,AF,.cv,CVE,Escudo,pt-CV,238,556000,
+CW,Curacao,NA,.cw,ANG,Guilder,"nl,pap",599,155000,
+CX,Christmas Island,AS,.cx,AUD,Dollar,"en,zh,ms-CC",61,1800,
+CY,Cyprus,EU,.cy,EUR,Euro,"el-CY,tr-CY,en",357,1210000,
+CZ,Czechia,EU,.cz,CZK,Koruna,"cs,sk",420,10700000,"PL,DE,SK,AT"
+DE,Germany,EU,.de,EUR,Euro,de,49,83200000,"CH,PL,NL,DK,BE,CZ,LU,FR,AT"
+DJ,Djibouti,AF,.dj,DJF,Franc,"fr-DJ,ar,so-DJ,aa",253,988000,"ER,ET,SO"
+DK,Denmark,EU,.dk,DKK,Krone,"da-DK,en,fo,de-DK",45,5830000,DE
+DM,Dominica,NA,.dm,XCD,Dollar,en-DM,1,72000,
+DO,Dominican Republic,NA,.do,DOP,Peso,es-DO,1,10800000,HT
+DZ,Algeria,AF,.dz,DZD,Dinar,ar-DZ,213,43900000,"NE,EH,LY,MR,TN,MA,ML"
+EC,Ecuador,SA,.ec,USD,Dollar,es-EC,593,17600000,"PE,CO"
+EE,Estonia,EU,.ee,EUR,Euro,"et,ru",372,1330000,"RU,LV"
+EG,Egypt,AF,.eg,EGP,Pound,"ar-EG,en,fr",20,102000000,"LY,SD,IL,PS"
+EH,Western Sahara,AF,.eh,MAD,Dirham,"ar,mey",212,597000,"DZ,MR,MA"
+ER,Eritrea,AF,.er,ERN,Nakfa,"aa-ER,ar,tig,kun,ti-ER",291,3550000,"ET,SD,DJ"
+ES,Spain,EU,.es,EUR,Euro,"es-ES,ca,gl,eu,oc",34,47400000,"AD,FR,GI,PT,MA"
+ET,Ethiopia,AF,.et,ETB,Birr,"am,en-ET,om-ET,ti-ET,so-ET,sid",251,115000000,"ER,KE,SD,SS,SO,DJ"
+FI,Finland,EU,.fi,EUR,Euro,"fi-FI,sv-FI,smn",358,5530000,"NO,RU,SE"
+FJ,Fiji,OC,.fj,FJD,Dollar,"en-FJ,fj",679,896000,
+FK,Falkland Islands,SA,.fk,FKP,Pound,en-FK,500,3400,
+FM,Micronesia,OC,.fm,USD,Dollar,"en-FM,chk,pon,yap,kos,uli,woe,nkr,kpg",691,115000,
+FO,Faroe Islands,EU,.fo,DKK,Krone,"fo,da-FO",298,49000,
+FR,France,EU,.fr,EUR,Euro,"fr-FR,frp,br,co,ca,eu,oc",33,67400000,"CH,DE,BE,LU,IT,AD,MC,ES"
+GA,Gabon,AF,.ga,XAF,Franc,fr-GA,241,2230000,"CM,GQ,CG"
+GB,United Kingdom,EU,.uk,GBP,Pound,"en-GB,cy-GB,gd",44,67200000,IE
+GD,Grenada,NA,.gd,XCD,Dollar,en-GD,1,113000,
+GE,Georgia,AS,.ge,GEL,Lari,"ka,ru,hy,az",995,3710000,"AM,AZ,TR,RU"
+GF,French Guiana,SA,.gf,EUR,Euro,fr-GF,594,295000,"SR,BR"
+GG,Guernsey,EU,.gg,GBP,Pound,"en,fr",44,63000,
+GH,Ghana,AF,.gh,GHS,Cedi,"en-GH,ak,ee,tw",233,31100000,"CI,TG,BF"
+GI,Gibraltar,EU,.gi,GIP,Pound,"en-GI,es,it,pt",350,34000,ES
+GL,Greenland,NA,.gl,DKK,Krone,"kl,da-GL,en",299,56000,
+GM,Gambia,AF,.gm,GMD,Dalasi,"en-GM,mnk,wof,wo,ff",220,2420000,SN
+GN,Guinea,AF,.gn,GNF,Franc,fr-GN,224,13100000,"LR,SN,SL,CI,GW,ML"
+GP,Guadeloupe,NA,.gp,EUR,Euro,fr-GP,590,396000,
+GQ,Equatorial Guinea,AF,.gq,XAF,Franc,"es-GQ,fr",240,1400000,"GA,CM"
+GR,Greece,EU,.gr,EUR,Euro,"el-GR,en,fr",30,10700000,"AL,MK,TR,BG"
+GS,South Georgia and the South Sandwich Islands,AN,.gs,GBP,Pound,en,,30,
+GT,Guatemala,NA,.gt,GTQ,Quetzal,es-GT,502,16900000,"MX,HN,BZ,SV"
+GU,Guam,OC,.gu,USD,Dollar,"en-GU,ch-GU",1,169000,
+GW,Guinea-Bissau,AF,.gw,XOF,Franc,"pt-GW,pov",245,1970000,"SN,GN"
+GY,Guyana,SA,.gy,GYD,Dollar,en-GY,592,787000,"SR,BR,VE"
+HK,Hong Kong,AS,.hk,HKD,Dollar,"zh-HK,yue,zh,en",852,7480000,CN
+HN,Honduras,NA,.hn,HNL,Lempira,es-HN,504,9900000,"GT,NI,SV"
+HR,Croatia,EU,.hr,HRK,Kuna,"hr-HR,sr",385,4050000,"HU,SI,BA,ME,RS"
+HT,Haiti,NA,.ht,HTG,Gourde,"ht,fr-HT",509,11400000,DO
+HU,Hungary,EU,.hu,HUF,Forint,hu-HU,36,9750000,"SK,SI,RO,UA,HR,AT,RS"
+ID,Indonesia,AS,.id,IDR,Rupiah,"id,en,nl,jv",62,274000000,"PG,TL,MY"
+IE,Ireland,EU,.ie,EUR,Euro,"en-IE,ga-IE",353,4990000,GB
+IL,Israel,AS,.il,ILS,Shekel,"he,ar-IL,en-IL,",972,9220000,"SY,JO,LB,EG,PS"
+IM,Isle of Man,EU,.im,GBP,Pound,"en,gv",44,85000,
+IN,India,AS,.in,INR,Rupee,"en-IN,hi,bn,te,mr,ta,ur,gu,kn,ml,or,pa,as,bh,sat,ks,ne,sd,kok,doi,mni,sit,sa,fr,lus,inc",91,1380000000,"CN,NP,MM,BT,PK,BD"
+IO,British Indian Ocean Territory,AS,.io,USD,Dollar,en-IO,246,3000,
+IQ,Iraq,AS,.iq,IQD,Dinar,"ar-IQ,ku,hy",964,40200000,"SY,SA,TR,IR,JO,KW"
+IR,Iran,AS,.ir,IRR,Rial,"fa-IR,ku",98,84000000,"TM,AF,IQ,AM,PK,AZ,TR"
+IS,Iceland,EU,.is,ISK,Krona,"is,en,de,da,sv,no",354,366000,
+IT,Italy,EU,.it,EUR,Euro,"it-IT,de-IT,fr-IT,sc,ca,co,sl",39,59600000,"CH,VA,SI,SM,FR,AT"
+JE,Jersey,EU,.je,GBP,Pound,"en,pt",44,100000,
+JM,Jamaica,NA,.jm,JMD,Dollar,en-JM,1,2960000,
+JO,Jordan,AS,.jo,JOD,Dinar,"ar-JO,en",962,10200000,"SY,SA,IQ,IL,PS"
+JP,Japan,AS,.jp,JPY,Yen,ja,81,126000000,
+KE,Kenya,AF,.ke,KES,Shilling,"en-KE,sw-KE",254,53800000,"ET,TZ,SS,SO,UG"
+KG,Kyrgyzstan,AS,.kg,KGS,Som,"ky,ru,uz",996,6590000,"CN,TJ,UZ,KZ"
+KH,Cambodia,AS,.kh,KHR,Riels,"km,fr,en",855,16700000,"LA,TH,VN"
+KI,Kiribati,OC,.ki,AUD,Dollar,"en-KI,gil",686,119000,
+KM,Comoros,AF,.km,KMF,Franc,"ar,fr-KM",269,870000,
+KN,Saint Kitts and Nevis,NA,.kn,XCD,Dollar,en-KN,1,53000,
+KP,North Korea,AS,.kp,KPW,Won,ko-KP,850,25800000,"CN,KR,RU"
+KR,South Korea,AS,.kr,KRW,Won,"ko-KR,en",82,51800000,KP
+XK,Kosovo,EU,,EUR,Euro,"sq,sr",383,1780000,"RS,AL,MK,ME"
+KW,Kuwait,AS,.kw,KWD,Dinar,"ar-KW,en",965,4270000,"SA,IQ"
+KY,Cayman Islands,NA,.ky,KYD,Dollar,en-KY,1,66000,
+KZ,Kazakhstan,AS,.kz,KZT,Tenge,"kk,ru",7,18800000,"TM,CN,KG,UZ,RU"
+LA,Laos,AS,.la,LAK,Kip,"lo,fr,en",856,7280000,"CN,MM,KH,TH,VN"
+LB,Lebanon,AS,.lb,LBP,Pound,"ar-LB,fr-LB,en,hy",961,6830000,"SY,IL"
+LC,Saint Lucia,NA,.lc,XCD,Dollar,en-LC,1,184000,
+LI,Liechtenstein,EU,.li,CHF,Franc,de-LI,423,38000,"CH,AT"
+LK,Sri Lanka,AS,.lk,LKR,Rupee,"si,ta,en",94,21900000,
+LR,Liberia,AF,.lr,LRD,Dollar,en-LR,231,5060000,"GN,CI,SL"
+LS,Lesotho,AF,.ls,LSL,Loti,"en-LS,st,zu,xh",266,2140000,ZA
+LT,Lithuania,EU,.lt,EUR,Euro,"lt,ru,pl",370,2790000,"PL,BY,RU,LV"
+LU,Luxembourg,EU,.lu,EUR,Euro,"lb,de-LU,fr-LU",352,632000,"DE,BE,FR"
+LV,Latvia,EU,.lv,EUR,Euro,"lv,ru,lt",371,1900000,"LT,EE,BY,RU"
+LY,Libya,AF,.ly,LYD,Dinar,"ar-LY,it,en",218,6870000,"TD,NE,DZ,TN,SD,EG"
+MA,Morocco,AF,.ma,MAD,Dirham,"ar-MA,ber,fr",212,36900000,"DZ,EH,ES"
+MC,Monaco,EU,.mc,EUR,Euro,"fr-MC,en,it",377,39000,FR
+MD,Moldova,EU,.md,MDL,Leu,"ro,ru,gag,tr",373,2620000,"RO,UA"
+ME,Montenegro,EU,.me,EUR,Euro,"sr,hu,bs,sq,hr,rom",382,621000,"AL,HR,BA,RS,XK"
+MF,Saint Martin,NA,.gp,EUR,Euro,fr,590,32000,SX
+MG,Madagascar,AF,.mg,MGA,Ariary,"fr-MG,mg",261,27700000,
+MH,Marshall Islands,OC,.mh,USD,Dollar,"mh,en-MH",692,59000,
+MK,Macedonia,EU,.mk,MKD,Denar,"mk,sq,tr,rmm,sr",389,2080000,"AL,GR,BG,RS,XK"
+ML,Mali,AF,.ml,XOF,Franc,"fr-ML,bm",223,20300000,"SN,NE,DZ,CI,GN,MR,BF"
+MM,Myanmar,AS,.mm,MMK,Kyat,my,95,54400000,"CN,LA,TH,BD,IN"
+MN,Mongolia,AS,.mn,MNT,Tugrik,"mn,ru",976,3280000,"CN,RU"
+MO,Macao,AS,.mo,MOP,Pataca,"zh,zh-MO,pt",853,649000,CN
+MP,Northern Mariana Islands,OC,.mp,USD,Dollar,"fil,tl,zh,ch-MP,en-MP",1,57000,
+MQ,Martinique,NA,.mq,EUR,Euro,fr-MQ,596,375000,
+MR,Mauritania,AF,.mr,MRO,Ouguiya,"ar-MR,fuc,snk,fr,mey,wo",222,4650000,"SN,DZ,EH,ML"
+MS,Montserrat,NA,.ms,XCD,Dollar,en-MS,1,5000,
+MT,Malta,EU,.mt,EUR,Euro,"mt,en-MT",356,516000,
+MU,Mauritius,AF,.mu,MUR,Rupee,"en-MU,bho,fr",230,1270000,
+MV,Maldives,AS,.mv,MVR,Rufiyaa,"dv,en",960,541000,
+MW,Malawi,AF,.mw,MWK,Kwacha,"ny,yao,tum,swk",265,19100000,"TZ,MZ,ZM"
+MX,Mexico,NA,.mx,MXN,Peso,es-MX,52,129000000,"GT,US,BZ"
+MY,Malaysia,AS,.my,MYR,Ringgit,"ms-MY,en,zh,ta,te,ml,pa,th",60,32400000,"BN,TH,ID"
+MZ,Mozambique,AF,.mz,MZN,Metical,"pt-MZ,vmw",258,31300000,"ZW,TZ,SZ,ZA,ZM,MW"
+NA,Namibia,AF,.na,NAD,Dollar,"en-NA,af,de,hz,naq",264,2540000,"ZA,BW,ZM,AO"
+NC,New Caledonia,OC,.nc,XPF,Franc,fr-NC,687,271000,
+NE,Niger,AF,.ne,XOF,Franc,"fr-NE,ha,kr,dje",227,24200000,"TD,BJ,DZ,LY,BF,ML,NG"
+NF,Norfolk Island,OC,.nf,AUD,Dollar,en-NF,672,1700,
+NG,Nigeria,AF,.ng,NGN,Naira,"en-NG,ha,yo,ig,ff",234,206000000,"TD,NE,BJ,CM"
+NI,Nicaragua,NA,.ni,NIO,Cordoba,"es-NI,en",505,6620000,"CR,HN"
+NL,Netherlands,EU,.nl,EUR,Euro,"nl-NL,fy-NL",31,17400000,"DE,BE"
+NO,Norway,EU,.no,NOK,Krone,"no,nb,nn,se,fi",47,5380000,"FI,RU,SE"
+NP,Nepal,AS,.np,NPR,Rupee,"ne,en",977,29100000,"CN,IN"
+NR,Nauru,OC,.nr,AUD,Dollar,"na,en-NR",674,11000,
+NU,Niue,OC,.nu,NZD,Dollar,"niu,en-NU",683,1600,
+NZ,New Zealand,OC,.nz,NZD,Dollar,"en-NZ,mi",64,5080000,
+OM,Oman,AS,.om,OMR,Rial,"ar-OM,en,bal,ur",968,5110000,"SA,YE,AE"
+PA,Panama,NA,.pa,PAB,Balboa,"es-PA,en",507,4310000,"CR,CO"
+PE,Peru,SA,.pe,PEN,Sol,"es-PE,qu,ay",51,33000000,"EC,CL,BO,BR,CO"
+PF,French Polynesia,OC,.pf,XPF,Franc,"fr-PF,ty",689,281000,
+PG,Papua New Guinea,OC,.pg,PGK,Kina,"en-PG,ho,meu,tpi",675,8950000,ID
+PH,Philippines,AS,.ph,PHP,Peso,"tl,en-PH,fil",63,110000000,
+PK,Pakistan,AS,.pk,PKR,Rupee,"ur-PK,en-PK,pa,sd,ps,brh",92,221000000,"CN,AF,IR,IN"
+PL,Poland,EU,.pl,PLN,Zloty,pl,48,37900000,"DE,LT,SK,CZ,BY,UA,RU"
+PM,Saint Pierre and Miquelon,NA,.pm,EUR,Euro,fr-PM,508,6000,
+PN,Pitcairn,OC,.pn,NZD,Dollar,en-PN,870,50,
+PR,Puerto Rico,NA,.pr,USD,Dollar,"en-PR,es-PR",1,3190000,
+PS,Palestinian Territory,AS,.ps,ILS,Shekel,ar-PS,970,5100000,"JO,IL,EG"
+PT,Portugal,EU,.pt,EUR,Euro,"pt-PT,mwl",351,10300000,ES
+PW,Palau,OC,.pw,USD,Dollar,"pau,sov,en-PW,tox,ja,fil,zh",680,18000,
+PY,Paraguay,SA,.py,PYG,Guarani,"es-PY,gn",595,7130000,"BO,BR,AR"
+QA,Qatar,AS,.qa,QAR,Rial,"ar-QA,es",974,2880000,SA
+RE,Reunion,AF,.re,EUR,Euro,fr-RE,262,860000,
+RO,Romania,EU,.ro,RON,Leu,"ro,hu,rom",40,19300000,"MD,HU,UA,BG,RS"
+RS,Serbia,EU,.rs,RSD,Dinar,"sr,hu,bs,rom",381,6900000,"BA,RO,HR,ME,BG,HU,MK,XK"
+RU,Russia,EU,.ru,RUB,Ruble,"ru",7,146000000,"GE,CN,BY,UA,KZ,LV,PL,EE,LT,FI,MN,NO,AZ,KP"
+RW,Rwanda,AF,.rw,RWF,Franc,"rw,en-RW,fr-RW,sw",250,12900000,"TZ,CD,BI,UG"
+SA,Saudi Arabia,AS,.sa,SAR,Rial,ar-SA,966,34800000,"QA,OM,IQ,YE,JO,AE,KW"
+SB,Solomon Islands,OC,.sb,SBD,Dollar,"en-SB,tpi",677,687000,
+SC,Seychelles,AF,.sc,SCR,Rupee,"en-SC,fr-SC",248,98000,
+SD,Sudan,AF,.sd,SDG,Pound,"ar-SD,en,fia",249,43800000,"SS,TD,LY,ET,EG,ER,CF"
+SS,South Sudan,AF,,SSP,Pound,en,211,11200000,"CD,CF,ET,KE,SD,UG"
+SE,Sweden,EU,.se,SEK,Krona,"sv-SE,se,sma,fi-SE",46,10400000,"NO,FI"
+SG,Singapore,AS,.sg,SGD,Dollar,"cmn,en-SG,ms-SG,ta-SG,zh-SG",65,5690000,
+SH,Saint Helena,AF,.sh,SHP,Pound,en-SH,290,6000,
+SI,Slovenia,EU,.si,EUR,Euro,"sl,sh",386,2100000,"HU,IT,HR,AT"
+SJ,Svalbard and Jan Mayen,EU,.sj,NOK,Krone,"no,ru",47,2600,
+SK,Slovakia,EU,.sk,EUR,Euro,"sk,hu",421,5460000,"PL,HU,CZ,UA,AT"
+SL,Sierra Leone,AF,.sl,SLL,Leone,"en-SL,men,tem",232,7980000,"LR,GN"
+SM,San Marino,EU,.sm,EUR,Euro,it-SM,378,34000,IT
+SN,Senegal,AF,.sn,XOF,Franc,"fr-SN,wo,fuc,mnk",221,16700000,"GN,MR,GW,GM,ML"
+SO,Somalia,AF,.so,SOS,Shilling,"so-SO,ar-SO,it,en-SO",252,15900000,"ET,KE,DJ"
+SR,Suriname,SA,.sr,SRD,Dollar,"nl-SR,en,srn,hns,jv",597,587000,"GY,BR,GF"
+ST,Sao Tome and Principe,AF,.st,STD,Dobra,pt-ST,239,219000,
+SV,El Salvador,NA,.sv,USD,Dollar,es-SV,503,6490000,"GT,HN"
+SX,Sint Maarten,NA,.sx,ANG,Guilder,"nl,en",1,41000,MF
+SY,Syria,AS,.sy,SYP,Pound,"ar-SY,ku,hy,arc,fr,en",963,17500000,"IQ,JO,IL,TR,LB"
+SZ,Swaziland,AF,.sz,SZL,Lilangeni,"en-SZ,ss-SZ",268,1160000,"ZA,MZ"
+TC,Turks and Caicos Islands,NA,.tc,USD,Dollar,en-TC,1,39000,
+TD,Chad,AF,.td,XAF,Franc,"fr-TD,ar-TD,sre",235,16400000,"NE,LY,CF,SD,CM,NG"
+TF,French Southern Territories,AN,.tf,EUR,Euro  ,fr,,140,
+TG,Togo,AF,.tg,XOF,Franc,"fr-TG,ee,hna,kbp,dag,ha",228,8280000,"BJ,GH,BF"
+TH,Thailand,AS,.th,THB,Baht,"th,en",66,69800000,"LA,MM,KH,MY"
+TJ,Tajikistan,AS,.tj,TJS,Somoni,"tg,ru",992,9540000,"CN,AF,KG,UZ"
+TK,Tokelau,OC,.tk,NZD,Dollar,"tkl,en-TK",690,1400,
+TL,East Timor,OC,.tl,USD,Dollar,"tet,pt-TL,id,en",670,1320000,ID
+TM,Turkmenistan,AS,.tm,TMT,Manat,"tk,ru,uz",993,6030000,"AF,IR,UZ,KZ"
+TN,Tunisia,AF,.tn,TND,Dinar,"ar-TN,fr",216,11800000,"DZ,LY"
+TO,Tonga,OC,.to,TOP,Pa'anga,"to,en-TO",676,106000,
+TR,Turkey,AS,.tr,TRY,Lira,"tr-TR,ku,diq,az,av",90,84300000,"SY,GE,IQ,IR,GR,AM,AZ,BG"
+TT,Trinidad and Tobago,NA,.tt,TTD,Dollar,"en-TT,hns,fr,es,zh",1,1400000,
+TV,Tuvalu,OC,.tv,AUD,Dollar,"tvl,en,sm,gil",688,12000,
+TW,Taiwan,AS,.tw,TWD,Dollar,"zh-TW,zh,nan,hak",886,23600000,
+TZ,Tanzania,AF,.tz,TZS,Shilling,"sw-TZ,en,ar",255,59700000,"MZ,KE,CD,RW,ZM,BI,UG,MW"
+UA,Ukraine,EU,.ua,UAH,Hryvnia,"uk,ru-UA,rom,pl,hu",380,44100000,"PL,MD,HU,SK,BY,RO,RU"
+UG,Uganda,AF,.ug,UGX,Shilling,"en-UG,lg,sw,ar",256,45700000,"TZ,KE,SS,CD,RW"
+UM,United States Minor Outlying Islands,OC,.um,USD,Dollar ,en-UM,1,0,
+US,United States,NA,.us,USD,Dollar,"en-US,es-US,haw,fr",1,331000000,"CA,MX"
+UY,Uruguay,SA,.uy,UYU,Peso,es-UY,598,3470000,"BR,AR"
+UZ,Uzbekistan,AS,.uz,UZS,Som,"uz,ru,tg",998,34200000,"TM,AF,KG,TJ,KZ"
+VA,Vatican,EU,.va,EUR,Euro,"la,it,fr",379,800,IT
+VC,Saint Vincent and the Grenadines,NA,.vc,XCD,Dollar,"en-VC,fr",1,111000,
+VE,Venezuela,SA,.ve,VEF,Bolivar,es-VE,58,28400000,"GY,BR,CO"
+VG,British Virgin Islands,NA,.vg,USD,Dollar,en-VG,1,30000,
+VI,U.S. Virgin Islands,NA,.vi,USD,Dollar,en-VI,1,106000,
+VN,Vietnam,AS,.vn,VND,Dong,"vi,en,fr,zh,km",84,97300000,"CN,LA,KH"
+VU,Vanuatu,OC,.vu,VUV,Vatu,"bi,en-VU,fr-VU",678,307000,
+WF,Wallis and Futuna,OC,.wf,XPF,Franc,"wls,fud,fr-WF",681,11000,
+WS,Samoa,OC,.ws,WST,Tala,"sm,en-WS",685,198000,
+YE,Yemen,AS,.ye,YER,Rial,ar-YE,967,29800000,"SA,OM"
+YT,Mayotte,AF,.yt,EUR,Euro,fr-YT,262,273000,
+ZA,South Africa,AF,.za,ZAR,Rand,"en-ZA,zu,xh,af,nso,tn,st,ts,ss,ve,nr",27,59300000,"ZW,SZ,MZ,BW,NA,LS"
+ZM,Zambia,AF,.zm,ZMW,Kwacha,"en-ZM,bem,loz,lun,lue,ny,toi",260,18400000,"ZW,TZ,NA,CD,AO,MW,MZ,BW"
+ZW,Zimbabwe,AF,.zw,ZWL,Dollar,"en-ZW,sn,nr,nd",263,14900000,"ZA,MZ,BW,ZM"
+CS,Serbia and Montenegro,EU,.cs,RSD,Dinar,"cu,hu,sq,sr",381,0,
+AN,Netherlands Antilles,NA,.an,ANG,Guilder,"nl-AN,en,es",599,0,
 `
@@ -0,0 +1,231 @@
+//go:build integration
+
+// Integration tests against MaxMind's official test databases.
+//
+//	go test -tags integration ./...
+//
+// The databases are downloaded from the MaxMind-DB repository on first run and
+// kept in the user cache dir. Set WEBGEO_TEST_DATA to a directory holding
+// GeoIP2-City-Test.mmdb to run offline.
+package webgeo
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testDataURL = "https://github.com/maxmind/MaxMind-DB/raw/main/test-data/"
+
+func testDB(t *testing.T, name string) string {
+	t.Helper()
+	dir := os.Getenv("WEBGEO_TEST_DATA")
+	if dir == "" {
+		cache, err := os.UserCacheDir()
+		if err != nil {
+			cache = os.TempDir()
+		}
+		dir = filepath.Join(cache, "webgeo-test-data")
+	}
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := download(testDataURL+name, path); err != nil {
+		t.Fatalf("Could not download %s: %v", name, err)
+	}
+	return path
+}
+
+func download(url, path string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func useCityTestDB(t *testing.T) {
+	t.Helper()
+	old := DBPath
+	DBPath = testDB(t, "GeoIP2-City-Test.mmdb")
+	FlushCache()
+	t.Cleanup(func() { DBPath = old })
+}
+
+func TestIntegrationGeolocate(t *testing.T) {
+	useCityTestDB(t)
+	tests := []struct {
+		ip      string
+		cc      string
+		country string
+		city    string
+	}{
+		{"81.2.69.160", "GB", "United Kingdom", "London"},
+		{"216.160.83.56", "US", "United States", "Milton"},
+		// IPv6 range with country but no city
+		{"2001:218::1", "JP", "Japan", ""},
+		// not in the database at all: every field missing
+		{"192.0.2.1", "", "", ""},
+	}
+	for _, tt := range tests {
+		geo, err := geolocate(net.ParseIP(tt.ip))
+		if err != nil {
+			t.Errorf("geolocate(%s): %v", tt.ip, err)
+			continue
+		}
+		if geo.Cc != tt.cc || geo.Country != tt.country || geo.City != tt.city {
+			t.Errorf("geolocate(%s) = %+v, want cc=%q country=%q city=%q", tt.ip, geo, tt.cc, tt.country, tt.city)
+		}
+	}
+}
+
+func TestIntegrationLocationFields(t *testing.T) {
+	useCityTestDB(t)
+	geo, err := geolocate(net.ParseIP("81.2.69.160"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if geo.TimeZone != "Europe/London" {
+		t.Errorf("TimeZone = %q, want Europe/London", geo.TimeZone)
+	}
+	if geo.ContinentCode != "EU" || geo.Continent != "Europe" {
+		t.Errorf("continent = %q %q, want EU Europe", geo.ContinentCode, geo.Continent)
+	}
+	if geo.IsInEuropeanUnion {
+		t.Errorf("IsInEuropeanUnion = true for GB")
+	}
+	if geo.RegisteredCountry != "US" || geo.RepresentedCountry != "" {
+		t.Errorf("registered/represented = %q %q, want US and none", geo.RegisteredCountry, geo.RepresentedCountry)
+	}
+}
+
+func TestIntegrationSubdivisions(t *testing.T) {
+	useCityTestDB(t)
+	geo, err := geolocate(net.ParseIP("81.2.69.160"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Subdivision{"ENG", "England"}
+	if len(geo.Subdivisions) == 0 || geo.Subdivisions[0] != want {
+		t.Fatalf("Subdivisions = %+v, want first %+v", geo.Subdivisions, want)
+	}
+	if geo.MostSpecificSubdivision != geo.Subdivisions[len(geo.Subdivisions)-1] {
+		t.Errorf("MostSpecificSubdivision = %+v, want the last subdivision", geo.MostSpecificSubdivision)
+	}
+}
+
+func TestIntegrationASN(t *testing.T) {
+	useCityTestDB(t)
+	ASNDBPath = testDB(t, "GeoLite2-ASN-Test.mmdb")
+	defer func() { ASNDBPath = "" }()
+	geo, err := geolocate(net.ParseIP("1.128.0.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if geo.AutonomousSystemNumber != 1221 || geo.AutonomousSystemOrganization != "Telstra Pty Ltd" {
+		t.Errorf("ASN = %d %q, want 1221 Telstra Pty Ltd", geo.AutonomousSystemNumber, geo.AutonomousSystemOrganization)
+	}
+}
+
+func TestIntegrationCalcCountryAndLangs(t *testing.T) {
+	useCityTestDB(t)
+	tests := []struct {
+		remoteAddr     string
+		acceptLanguage string
+		country        string
+		langs          []string
+	}{
+		{"81.2.69.160:4321", "", "GB", []string{"en-GB", "cy-GB"}},
+		{"81.2.69.160:4321", "pl", "GB", []string{"pl", "en-GB", "cy-GB"}},
+		{"81.2.69.160:4321", "en;q=0.5, de", "GB", []string{"de", "en-GB", "cy-GB"}},
+		{"[2001:218::1]:4321", "", "JP", []string{"ja"}},
+		{"2001:218::1", "", "JP", []string{"ja"}},
+		{"192.0.2.1:4321", "de-DE", "ZZ", []string{"de-DE"}},
+		{"@", "", "ZZ", []string{}},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = tt.remoteAddr
+		if tt.acceptLanguage != "" {
+			r.Header.Set("Accept-Language", tt.acceptLanguage)
+		}
+		country, langs := CalcCountryAndLangs(r)
+		if country != tt.country {
+			t.Errorf("%s: country = %q, want %q", tt.remoteAddr, country, tt.country)
+		}
+		if strings.Join(langs, ",") != strings.Join(tt.langs, ",") {
+			t.Errorf("%s: langs = %v, want %v", tt.remoteAddr, langs, tt.langs)
+		}
+	}
+}
+
+// Anonymous proxy ranges carry traits but must still go through the
+// pipeline like any other address.
+func TestIntegrationAnonymousRange(t *testing.T) {
+	useCityTestDB(t)
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "67.43.156.1:4321"
+	country, _ := CalcCountryAndLangs(r)
+	if len(country) != 2 {
+		t.Errorf("country = %q, want a 2 letter code", country)
+	}
+}
+
+func TestIntegrationDump(t *testing.T) {
+	useCityTestDB(t)
+	d := Dump()
+	if d.Database.Error != "" {
+		t.Fatalf("database error: %s", d.Database.Error)
+	}
+	if d.Database.Type != "GeoIP2-City" {
+		t.Errorf("database type = %q, want GeoIP2-City", d.Database.Type)
+	}
+}
+
+func TestIntegrationLookupBatch(t *testing.T) {
+	useCityTestDB(t)
+	ips := []netip.Addr{
+		netip.MustParseAddr("81.2.69.160"),
+		netip.MustParseAddr("2001:218::1"),
+		{},
+		netip.MustParseAddr("81.2.69.160"),
+	}
+	want := []string{"GB", "JP", "ZZ", "GB"}
+	results := LookupBatch(ips)
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, res := range results {
+		if res.Country != want[i] {
+			t.Errorf("%v: country = %q, want %q", ips[i], res.Country, want[i])
+		}
+	}
+}
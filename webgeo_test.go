@@ -0,0 +1,35 @@
+package webgeo
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestMaxLangsFallback(t *testing.T) {
+	tests := []struct {
+		max    int
+		accept string
+		want   []string
+	}{
+		{1, "de, fr", []string{"de"}},
+		{1, "", []string{"en"}},
+		{2, "de, fr, it", []string{"de", "en"}},
+		{2, "de, en, fr", []string{"de", "en"}},
+		{3, "de, fr", []string{"de", "fr", "en"}},
+		{0, "de, fr", []string{"de", "fr", "en"}},
+	}
+	for _, tt := range tests {
+		g := New(WithMaxLangs(tt.max), WithFallbackLang(language.English))
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "@" // no client IP, no lookup
+		if tt.accept != "" {
+			r.Header.Set("Accept-Language", tt.accept)
+		}
+		if _, langs := g.CalcCountryAndLangs(r); !reflect.DeepEqual(langs, tt.want) {
+			t.Errorf("max %d, %q: %v, want %v", tt.max, tt.accept, langs, tt.want)
+		}
+	}
+}